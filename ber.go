@@ -3,6 +3,8 @@ package asn1
 import (
 	"fmt"
 	"io"
+	"math"
+	"math/bits"
 )
 
 // EncodeTLV encodes a Tag-Length-Value structure using BER rules
@@ -110,6 +112,23 @@ func EncodeLength(length int) ([]byte, error) {
 
 // DecodeTLV decodes a Tag-Length-Value structure from BER encoding
 func DecodeTLV(data []byte) (*ASN1Value, int, error) {
+	val, offset, err := decodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Keep the verbatim encoding; the value shares its backing array
+	raw := make([]byte, offset)
+	copy(raw, data[:offset])
+	val.value = raw[offset-len(val.value):]
+	val.raw = raw
+
+	return val, offset, nil
+}
+
+// decodeTLV is DecodeTLV without the copy: the value and its verbatim
+// encoding share the backing array of data
+func decodeTLV(data []byte) (*ASN1Value, int, error) {
 	if len(data) == 0 {
 		return nil, 0, fmt.Errorf("empty data")
 	}
@@ -134,17 +153,13 @@ func DecodeTLV(data []byte) (*ASN1Value, int, error) {
 	}
 	offset += lengthLen
 
-	// Check if we have enough data for the value
-	if offset+length > len(data) {
+	// Check if we have enough data for the value, without overflowing offset+length
+	if length > len(data)-offset {
 		return nil, 0, fmt.Errorf("insufficient data for value: need %d bytes, have %d", length, len(data)-offset)
 	}
 
-	// Extract value
-	value := make([]byte, length)
-	copy(value, data[offset:offset+length])
-	offset += length
-
-	return NewASN1Value(tag, value), offset, nil
+	end := offset + length
+	return &ASN1Value{tag: tag, value: data[offset:end:end], raw: data[:end:end]}, end, nil
 }
 
 // DecodeTag decodes an ASN.1 tag from BER encoding
@@ -184,7 +199,7 @@ func DecodeTag(data []byte) (Tag, int, error) {
 		offset++
 
 		// Check for overflow
-		if tagNumber > (1<<(32-7))-1 {
+		if tagNumber > math.MaxInt>>7 {
 			return Tag{}, 0, fmt.Errorf("tag number too large")
 		}
 
@@ -224,16 +239,22 @@ func DecodeLength(data []byte) (int, int, error) {
 		return 0, 0, fmt.Errorf("indefinite length not supported")
 	}
 
-	if numLengthBytes > 4 {
-		return 0, 0, fmt.Errorf("length too large: %d bytes", numLengthBytes)
+	// 0xFF is reserved for future extensions (X.690 8.1.3.5)
+	if numLengthBytes == 0x7F {
+		return 0, 0, fmt.Errorf("reserved length octet 0xFF")
 	}
 
 	if len(data) < 1+numLengthBytes {
 		return 0, 0, fmt.Errorf("insufficient data for length: need %d bytes, have %d", 1+numLengthBytes, len(data))
 	}
 
+	// Lengths up to the size of an int are supported: 8 bytes on 64-bit
+	// platforms, 4 bytes on 32-bit ones. Leading zero bytes do not count.
 	length := 0
 	for i := 1; i <= numLengthBytes; i++ {
+		if length > math.MaxInt>>8 {
+			return 0, 0, fmt.Errorf("length too large: exceeds %d bits", bits.UintSize-1)
+		}
 		length = (length << 8) | int(data[i])
 	}
 
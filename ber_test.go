@@ -0,0 +1,139 @@
+package asn1
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+type lengthTest struct {
+	name     string
+	data     []byte
+	length   int
+	consumed int
+	wantErr  bool
+}
+
+func TestDecodeLength(t *testing.T) {
+	tests := []lengthTest{
+		{"short form", []byte{0x7F}, 127, 1, false},
+		{"one byte", []byte{0x81, 0x80}, 128, 2, false},
+		{"four bytes", []byte{0x84, 0x7F, 0xFF, 0xFF, 0xFF}, math.MaxInt32, 5, false},
+		{"leading zeros", []byte{0x89, 0, 0, 0, 0, 0, 0, 0, 0, 0x05}, 5, 10, false},
+		{"indefinite", []byte{0x80}, 0, 0, true},
+		{"reserved", []byte{0xFF}, 0, 0, true},
+		{"truncated", []byte{0x82, 0x01}, 0, 0, true},
+		{"too large", []byte{0x89, 0x01, 0, 0, 0, 0, 0, 0, 0, 0}, 0, 0, true},
+	}
+	if bits.UintSize == 64 {
+		// Shifted at run time: the constants would overflow int on 32-bit platforms
+		one := 1
+		tests = append(tests,
+			lengthTest{"five bytes", []byte{0x85, 0x01, 0x00, 0x00, 0x00, 0x00}, one << 32, 6, false},
+			lengthTest{"eight bytes", []byte{0x88, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, math.MaxInt, 9, false},
+			lengthTest{"overflows int", []byte{0x88, 0x80, 0, 0, 0, 0, 0, 0, 0}, 0, 0, true},
+		)
+	} else {
+		tests = append(tests, lengthTest{"overflows int", []byte{0x84, 0x80, 0, 0, 0}, 0, 0, true})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			length, consumed, err := DecodeLength(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got length %d", length)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if length != tt.length || consumed != tt.consumed {
+				t.Errorf("got length %d consumed %d, want %d and %d", length, consumed, tt.length, tt.consumed)
+			}
+		})
+	}
+}
+
+func TestDecodeTagNumberLimit(t *testing.T) {
+	// The largest tag number that fits in an int round-trips
+	encoded, err := EncodeTag(NewContextSpecificTag(math.MaxInt, false))
+	if err != nil {
+		t.Fatalf("EncodeTag failed: %v", err)
+	}
+	tag, consumed, err := DecodeTag(encoded)
+	if err != nil {
+		t.Fatalf("DecodeTag(%x) failed: %v", encoded, err)
+	}
+	if tag.Number != math.MaxInt || consumed != len(encoded) {
+		t.Errorf("DecodeTag(%x) = %d from %d bytes", encoded, tag.Number, consumed)
+	}
+
+	// One more bit overflows int
+	overflow := []byte{0x9F, 0x80 | 1<<((bits.UintSize-1)%7)}
+	for i := 0; i < (bits.UintSize-1)/7; i++ {
+		overflow = append(overflow, 0x80)
+	}
+	overflow[len(overflow)-1] = 0x00
+	if tag, _, err := DecodeTag(overflow); err == nil {
+		t.Errorf("DecodeTag(%x) = %d, expected error", overflow, tag.Number)
+	}
+}
+
+func TestEncodeLengthRoundTrip(t *testing.T) {
+	lengths := []int{0, 127, 128, 255, 256, 65535, 1 << 24, math.MaxInt32}
+	if bits.UintSize == 64 {
+		five := 5
+		lengths = append(lengths, five<<30, math.MaxInt)
+	}
+	for _, length := range lengths {
+		encoded, err := EncodeLength(length)
+		if err != nil {
+			t.Fatalf("EncodeLength(%d) failed: %v", length, err)
+		}
+		decoded, consumed, err := DecodeLength(encoded)
+		if err != nil {
+			t.Fatalf("DecodeLength(%x) failed: %v", encoded, err)
+		}
+		if decoded != length || consumed != len(encoded) {
+			t.Errorf("length %d: decoded %d from %d of %d bytes", length, decoded, consumed, len(encoded))
+		}
+	}
+}
+
+func TestDecodeTLVLengthBeyondData(t *testing.T) {
+	// An OCTET STRING header declaring the largest length with none of its
+	// content: offset+length would overflow
+	data := []byte{0x04, 0x88, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00}
+	if bits.UintSize != 64 {
+		data = []byte{0x04, 0x84, 0x7F, 0xFF, 0xFF, 0xFF, 0x00}
+	}
+	if _, _, err := DecodeTLV(data); err == nil {
+		t.Fatal("expected error for length beyond the data")
+	}
+}
+
+func TestDecodeOptionsMaxLength(t *testing.T) {
+	content := make([]byte, 300)
+	inner := append([]byte{0x04, 0x82, 0x01, 0x2C}, content...)
+	data := append([]byte{0x30, 0x82, 0x01, 0x30}, inner...)
+
+	if _, _, err := DecodeWithOptions(data, &DecodeOptions{MaxLength: 304}); err != nil {
+		t.Fatalf("unexpected error at the limit: %v", err)
+	}
+
+	_, _, err := DecodeWithOptions(data, &DecodeOptions{MaxLength: 303})
+	limitErr := expectLimitError(t, err, "MaxLength")
+	if limitErr.Offset != 0 {
+		t.Errorf("expected offset 0, got %d", limitErr.Offset)
+	}
+
+	var v struct {
+		Data []byte `asn1:"octetstring"`
+	}
+	opts := DefaultMarshalOptions()
+	opts.Limits = &DecodeOptions{MaxLength: 303}
+	err = UnmarshalWithOptions(data, &v, opts)
+	expectLimitError(t, err, "MaxLength")
+}
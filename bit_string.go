@@ -11,10 +11,14 @@ type ASN1BitString struct {
 	unusedBits int // number of unused bits in the last byte (0-7)
 }
 
-// NewBitString creates a new ASN1BitString
-func NewBitString(value []byte, unusedBits int) *ASN1BitString {
+// NewBitStringChecked creates a new ASN1BitString, reporting an error if
+// unusedBits is not between 0 and 7, or is not 0 for an empty value
+func NewBitStringChecked(value []byte, unusedBits int) (*ASN1BitString, error) {
 	if unusedBits < 0 || unusedBits > 7 {
-		panic(fmt.Sprintf("unused bits must be between 0 and 7, got %d", unusedBits))
+		return nil, fmt.Errorf("unused bits must be between 0 and 7, got %d", unusedBits)
+	}
+	if len(value) == 0 && unusedBits != 0 {
+		return nil, fmt.Errorf("unused bits must be 0 when no data bytes present")
 	}
 	
 	// Create a copy to prevent external modification
@@ -24,13 +28,25 @@ func NewBitString(value []byte, unusedBits int) *ASN1BitString {
 	return &ASN1BitString{
 		value:      copied,
 		unusedBits: unusedBits,
+	}, nil
+}
+
+// NewBitString creates a new ASN1BitString.
+// It panics if unusedBits is invalid; use NewBitStringChecked for values
+// that are not known to be valid.
+func NewBitString(value []byte, unusedBits int) *ASN1BitString {
+	b, err := NewBitStringChecked(value, unusedBits)
+	if err != nil {
+		panic(err.Error())
 	}
+	return b
 }
 
-// NewBitStringFromBits creates a new ASN1BitString from a bit string
-func NewBitStringFromBits(bits string) *ASN1BitString {
+// ParseBitString creates a new ASN1BitString from a string of '0' and '1'
+// characters, reporting an error for any other character
+func ParseBitString(bits string) (*ASN1BitString, error) {
 	if len(bits) == 0 {
-		return &ASN1BitString{value: []byte{}, unusedBits: 0}
+		return &ASN1BitString{value: []byte{}, unusedBits: 0}, nil
 	}
 	
 	// Calculate how many bytes we need
@@ -45,14 +61,25 @@ func NewBitStringFromBits(bits string) *ASN1BitString {
 			bitIndex := 7 - (i % 8) // MSB first
 			value[byteIndex] |= 1 << uint(bitIndex)
 		} else if bit != '0' {
-			panic(fmt.Sprintf("invalid bit character: %c", bit))
+			return nil, fmt.Errorf("invalid bit character: %c", bit)
 		}
 	}
 	
 	return &ASN1BitString{
 		value:      value,
 		unusedBits: unusedBits,
+	}, nil
+}
+
+// NewBitStringFromBits creates a new ASN1BitString from a bit string.
+// It panics if bits contains characters other than '0' and '1'; use
+// ParseBitString for values that are not known to be valid.
+func NewBitStringFromBits(bits string) *ASN1BitString {
+	b, err := ParseBitString(bits)
+	if err != nil {
+		panic(err.Error())
 	}
+	return b
 }
 
 // Value returns the bit string value bytes
@@ -180,5 +207,9 @@ func DecodeBitString(data []byte) (*ASN1BitString, int, error) {
 		return nil, 0, err
 	}
 
-	return NewBitString(value, unusedBits), consumed, nil
+	bitString, err := NewBitStringChecked(value, unusedBits)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bitString, consumed, nil
 }
\ No newline at end of file
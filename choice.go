@@ -106,7 +106,8 @@ func DecodeChoice(data []byte, alternatives []func([]byte) (ASN1Object, int, err
 }
 
 // DecodeChoiceWithTags attempts to decode a CHOICE from the given data
-// by matching the tag against expected tags for each alternative
+// by matching the tag against expected tags for each alternative.
+// [ChoiceSpec.Decode] does the same with named alternatives and a prebuilt tag table.
 func DecodeChoiceWithTags(data []byte, expectedTags []Tag, decoders []func([]byte) (ASN1Object, int, error)) (*ASN1Choice, int, error) {
 	if len(data) == 0 {
 		return nil, 0, fmt.Errorf("empty data")
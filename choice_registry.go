@@ -0,0 +1,204 @@
+package asn1
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ChoiceAlternative describes one alternative of a CHOICE registered with
+// RegisterChoice
+type ChoiceAlternative struct {
+	// Tag is the tag of the alternative, such as [APPLICATION 0] or [1].
+	// A universal Tag, such as the zero Tag, leaves the alternative
+	// untagged, identified by the tag of its type.
+	Tag Tag
+	// Type is a prototype of the concrete Go type, a value or a pointer,
+	// which must implement the CHOICE interface
+	Type interface{}
+	// Explicit wraps the alternative's encoding in Tag instead of replacing its tag
+	Explicit bool
+}
+
+// registeredChoice is a CHOICE interface and its alternatives
+type registeredChoice struct {
+	iface        reflect.Type
+	alternatives []registeredAlternative
+}
+
+type registeredAlternative struct {
+	typ      reflect.Type
+	tag      Tag
+	tagged   bool
+	explicit bool
+	tags     []Tag // possible first tags, used to pick the alternative
+}
+
+var choiceRegistry = struct {
+	mu      sync.RWMutex
+	choices map[reflect.Type]*registeredChoice
+}{choices: make(map[reflect.Type]*registeredChoice)}
+
+// RegisterChoice registers the interface type I as a CHOICE between the
+// given alternatives. Fields of type I then marshal the alternative they
+// hold with its tag, and unmarshaling fills them with a new value of the
+// concrete type whose tag matches, which makes sealed interfaces usable for
+// protocol messages such as ROSE operations or LDAP protocolOp:
+//
+//	type ProtocolOp interface{ isProtocolOp() }
+//
+//	// protocolOp CHOICE { bindRequest [APPLICATION 0] ..., bindResponse [APPLICATION 1] ... }
+//	asn1.RegisterChoice[ProtocolOp](
+//		asn1.ChoiceAlternative{Tag: asn1.NewTag(1, true, 0), Type: BindRequest{}},
+//		asn1.ChoiceAlternative{Tag: asn1.NewTag(1, true, 1), Type: BindResponse{}},
+//	)
+//
+// Registering I again replaces its alternatives.
+func RegisterChoice[I any](alternatives ...ChoiceAlternative) error {
+	iface := reflect.TypeOf((*I)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		return fmt.Errorf("CHOICE type %v is not an interface", iface)
+	}
+	if len(alternatives) == 0 {
+		return fmt.Errorf("CHOICE %v has no alternatives", iface)
+	}
+
+	choice := &registeredChoice{iface: iface}
+	seen := make(map[[2]int]reflect.Type)
+	for _, alternative := range alternatives {
+		if alternative.Type == nil {
+			return fmt.Errorf("CHOICE %v: alternative type cannot be nil", iface)
+		}
+		typ := reflect.TypeOf(alternative.Type)
+		if !typ.Implements(iface) {
+			return fmt.Errorf("CHOICE %v: %v does not implement it", iface, typ)
+		}
+
+		registered := registeredAlternative{
+			typ:      typ,
+			tag:      alternative.Tag,
+			tagged:   alternative.Tag.Class != 0,
+			explicit: alternative.Explicit,
+		}
+		if registered.tagged {
+			registered.tags = []Tag{alternative.Tag}
+		} else {
+			tags, ok := typeFirstTags(typ, DefaultMarshalOptions(), map[reflect.Type]bool{})
+			if !ok {
+				return fmt.Errorf("CHOICE %v: untagged alternative %v has no known tag", iface, typ)
+			}
+			registered.tags = tags
+		}
+
+		for _, tag := range registered.tags {
+			key := [2]int{tag.Class, tag.Number}
+			if other, ok := seen[key]; ok {
+				return fmt.Errorf("CHOICE %v: %v and %v share tag %s", iface, other, typ, tag.TagString())
+			}
+			seen[key] = typ
+		}
+		choice.alternatives = append(choice.alternatives, registered)
+	}
+
+	choiceRegistry.mu.Lock()
+	defer choiceRegistry.mu.Unlock()
+	choiceRegistry.choices[iface] = choice
+	return nil
+}
+
+// lookupChoice returns the registration of a CHOICE interface, or nil
+func lookupChoice(t reflect.Type) *registeredChoice {
+	if t.Kind() != reflect.Interface {
+		return nil
+	}
+	choiceRegistry.mu.RLock()
+	defer choiceRegistry.mu.RUnlock()
+	return choiceRegistry.choices[t]
+}
+
+// firstTags returns the tags any alternative can start with
+func (c *registeredChoice) firstTags() []Tag {
+	var tags []Tag
+	for _, alternative := range c.alternatives {
+		tags = append(tags, alternative.tags...)
+	}
+	return tags
+}
+
+// marshal encodes the alternative held by the interface value v
+func (c *registeredChoice) marshal(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
+	if v.IsNil() {
+		return nil, fmt.Errorf("CHOICE %v is nil", c.iface)
+	}
+	value := v.Elem()
+
+	for _, alternative := range c.alternatives {
+		if alternative.typ != value.Type() {
+			continue
+		}
+		obj, err := marshalValue(value, opts)
+		if err != nil {
+			return nil, fmt.Errorf("CHOICE %v alternative %v: %w", c.iface, value.Type(), err)
+		}
+		if !alternative.tagged {
+			return obj, nil
+		}
+		if alternative.explicit {
+			wrapped := NewStructured(NewTag(alternative.tag.Class, true, alternative.tag.Number))
+			wrapped.Add(obj)
+			return wrapped, nil
+		}
+		return replaceTagClass(obj, alternative.tag.Class, alternative.tag.Number), nil
+	}
+	return nil, fmt.Errorf("%v is not a registered alternative of CHOICE %v", value.Type(), c.iface)
+}
+
+// unmarshal sets v to a new value of the alternative whose tag matches obj
+func (c *registeredChoice) unmarshal(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error {
+	got := obj.Tag()
+	for _, alternative := range c.alternatives {
+		if !alternative.matches(got) {
+			continue
+		}
+
+		element := obj
+		if alternative.tagged {
+			if alternative.explicit {
+				wrapped, ok := obj.(*ASN1Structured)
+				if !ok || len(wrapped.Elements()) != 1 {
+					return fmt.Errorf("CHOICE %v: explicit %s does not hold one element", c.iface, got.TagString())
+				}
+				element = wrapped.Elements()[0]
+			} else if tag, ok := typeImplicitTag(alternative.typ, opts); ok {
+				// IMPLICIT tagging: restore the type's own tag
+				element = restoreUniversalTag(obj, tag)
+			}
+		}
+
+		typ := alternative.typ
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		target := reflect.New(typ)
+		if err := unmarshalValue(element, target.Elem(), opts); err != nil {
+			return fmt.Errorf("CHOICE %v alternative %v: %w", c.iface, alternative.typ, err)
+		}
+		if alternative.typ.Kind() == reflect.Ptr {
+			v.Set(target)
+		} else {
+			v.Set(target.Elem())
+		}
+		return nil
+	}
+	return fmt.Errorf("no alternative of CHOICE %v matches tag %s", c.iface, got.TagString())
+}
+
+// matches reports whether an element with the given tag encodes the alternative
+func (a *registeredAlternative) matches(got Tag) bool {
+	for _, tag := range a.tags {
+		if tag.Class == got.Class && tag.Number == got.Number {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package asn1
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type protocolOp interface{ isProtocolOp() }
+
+type bindRequest struct {
+	Version int64  `asn1:"integer"`
+	Name    string `asn1:"utf8string"`
+}
+
+type unbindRequest struct{}
+
+type abandonRequest int64
+
+func (bindRequest) isProtocolOp()    {}
+func (*unbindRequest) isProtocolOp() {}
+func (abandonRequest) isProtocolOp() {}
+
+type ldapMessage struct {
+	MessageID  int64      `asn1:"integer"`
+	ProtocolOp protocolOp `asn1:"choice"`
+}
+
+func init() {
+	err := RegisterChoice[protocolOp](
+		ChoiceAlternative{Tag: NewTag(1, true, 0), Type: bindRequest{}},
+		ChoiceAlternative{Tag: NewTag(1, true, 2), Type: &unbindRequest{}},
+		ChoiceAlternative{Tag: NewTag(1, false, 16), Type: abandonRequest(0)},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisteredChoiceRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		op   protocolOp
+		want []byte
+	}{
+		{"bind", bindRequest{Version: 3, Name: "a"}, []byte{0x30, 0x0B, 0x02, 0x01, 0x01, 0x60, 0x06, 0x02, 0x01, 0x03, 0x0C, 0x01, 'a'}},
+		{"unbind", &unbindRequest{}, []byte{0x30, 0x05, 0x02, 0x01, 0x01, 0x62, 0x00}},
+		{"abandon", abandonRequest(7), []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x50, 0x01, 0x07}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := Marshal(ldapMessage{MessageID: 1, ProtocolOp: tt.op})
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if !bytes.Equal(encoded, tt.want) {
+				t.Errorf("Marshal() = %x, want %x", encoded, tt.want)
+			}
+
+			var decoded ldapMessage
+			if err := Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			switch op := decoded.ProtocolOp.(type) {
+			case bindRequest:
+				if op != tt.op {
+					t.Errorf("Unmarshal() = %+v, want %+v", op, tt.op)
+				}
+			case *unbindRequest:
+				if _, ok := tt.op.(*unbindRequest); !ok {
+					t.Errorf("Unmarshal() = %T, want %T", op, tt.op)
+				}
+			case abandonRequest:
+				if op != tt.op {
+					t.Errorf("Unmarshal() = %v, want %v", op, tt.op)
+				}
+			default:
+				t.Errorf("Unmarshal() = %T", op)
+			}
+		})
+	}
+}
+
+type roseComponent interface{ isROSE() }
+
+type roseInvoke struct {
+	InvokeID int64 `asn1:"integer"`
+	OpCode   int64 `asn1:"integer"`
+}
+
+type roseReject struct {
+	InvokeID int64 `asn1:"integer"`
+}
+
+func (roseInvoke) isROSE() {}
+func (roseReject) isROSE() {}
+
+func TestRegisteredChoiceExplicitAndOptional(t *testing.T) {
+	err := RegisterChoice[roseComponent](
+		ChoiceAlternative{Tag: NewContextSpecificTag(1, true), Type: roseInvoke{}},
+		ChoiceAlternative{Tag: NewContextSpecificTag(4, true), Type: roseReject{}, Explicit: true},
+	)
+	if err != nil {
+		t.Fatalf("RegisterChoice() error = %v", err)
+	}
+
+	type message struct {
+		Component roseComponent `asn1:",optional"`
+		Note      string        `asn1:"utf8string"`
+	}
+
+	encoded, err := Marshal(message{Component: roseReject{InvokeID: 2}, Note: "n"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x0A, 0xA4, 0x05, 0x30, 0x03, 0x02, 0x01, 0x02, 0x0C, 0x01, 'n'}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+
+	var decoded message
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if reject, ok := decoded.Component.(roseReject); !ok || reject.InvokeID != 2 || decoded.Note != "n" {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+
+	// An absent component leaves the UTF8String for Note
+	decoded = message{}
+	if err := Unmarshal([]byte{0x30, 0x03, 0x0C, 0x01, 'n'}, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Component != nil || decoded.Note != "n" {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+}
+
+type otherOp struct{}
+
+func (otherOp) isProtocolOp() {}
+
+func TestRegisteredChoiceErrors(t *testing.T) {
+	if _, err := Marshal(ldapMessage{MessageID: 1, ProtocolOp: otherOp{}}); err == nil {
+		t.Error("Marshal() accepted an unregistered alternative")
+	}
+
+	data := []byte{0x30, 0x05, 0x02, 0x01, 0x01, 0x63, 0x00}
+	var decoded ldapMessage
+	if err := Unmarshal(data, &decoded); err == nil || !strings.Contains(err.Error(), "no alternative") {
+		t.Errorf("Unmarshal() error = %v, want no alternative error", err)
+	}
+
+	type duplicate interface{ isProtocolOp() }
+	err := RegisterChoice[duplicate](
+		ChoiceAlternative{Type: bindRequest{}},
+		ChoiceAlternative{Type: otherOp{}},
+	)
+	if err == nil || !strings.Contains(err.Error(), "share tag") {
+		t.Errorf("RegisterChoice() error = %v, want shared tag error", err)
+	}
+	if err := RegisterChoice[bindRequest](ChoiceAlternative{Type: bindRequest{}}); err == nil {
+		t.Error("RegisterChoice() accepted a non-interface type")
+	}
+}
+
+func TestRegisteredChoiceErrorPath(t *testing.T) {
+	// bindRequest whose Name is an INTEGER at offset 10
+	data := []byte{0x30, 0x0B, 0x02, 0x01, 0x01, 0x60, 0x06, 0x02, 0x01, 0x03, 0x02, 0x01, 0x05}
+	var decoded ldapMessage
+	err := Unmarshal(data, &decoded)
+	var structural *StructuralError
+	if !errors.As(err, &structural) {
+		t.Fatalf("Unmarshal() error = %v, want *StructuralError", err)
+	}
+	if structural.Path != "ProtocolOp.Name" || structural.Offset != 10 {
+		t.Errorf("error at %q offset %d, want ProtocolOp.Name offset 10", structural.Path, structural.Offset)
+	}
+}
@@ -0,0 +1,247 @@
+package asn1
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ChoiceSpecAlternative is a named alternative of a ChoiceSpec
+type ChoiceSpecAlternative struct {
+	Name string // identifier of the alternative, reported by ChoiceID
+	Tag  Tag    // tag the alternative is encoded with; the constructed bit is ignored
+	// Decode decodes the complete encoding of the alternative. When nil the
+	// alternative decodes to the generic object for its encoding.
+	Decode func([]byte) (ASN1Object, int, error)
+}
+
+// ChoiceSpec describes the alternatives of a CHOICE type. Decoding looks the
+// incoming tag up in a table built by NewChoiceSpec, so it takes constant
+// time however many alternatives there are, and the resulting ASN1Choice
+// carries the name of the alternative as its ChoiceID. A ChoiceSpec is
+// immutable and safe for concurrent use.
+type ChoiceSpec struct {
+	alternatives []ChoiceSpecAlternative
+	byTag        map[choiceTagKey]int
+	byName       map[string]int
+}
+
+// choiceTagKey identifies a tag by class and number
+type choiceTagKey struct {
+	class  int
+	number int
+}
+
+// NewChoiceSpec creates a ChoiceSpec. Names and tags must be distinct.
+func NewChoiceSpec(alternatives ...ChoiceSpecAlternative) (*ChoiceSpec, error) {
+	if len(alternatives) == 0 {
+		return nil, fmt.Errorf("choice spec has no alternatives")
+	}
+
+	spec := &ChoiceSpec{
+		alternatives: make([]ChoiceSpecAlternative, len(alternatives)),
+		byTag:        make(map[choiceTagKey]int, len(alternatives)),
+		byName:       make(map[string]int, len(alternatives)),
+	}
+	copy(spec.alternatives, alternatives)
+
+	for i, alternative := range spec.alternatives {
+		if alternative.Name == "" {
+			return nil, fmt.Errorf("choice alternative %d has no name", i)
+		}
+		if _, ok := spec.byName[alternative.Name]; ok {
+			return nil, fmt.Errorf("duplicate choice alternative %q", alternative.Name)
+		}
+		key := choiceTagKey{alternative.Tag.Class, alternative.Tag.Number}
+		if other, ok := spec.byTag[key]; ok {
+			return nil, fmt.Errorf("choice alternatives %q and %q share tag %s",
+				spec.alternatives[other].Name, alternative.Name, alternative.Tag.TagString())
+		}
+		spec.byName[alternative.Name] = i
+		spec.byTag[key] = i
+	}
+	return spec, nil
+}
+
+// Names returns the names of the alternatives in the order they were given
+func (s *ChoiceSpec) Names() []string {
+	names := make([]string, len(s.alternatives))
+	for i, alternative := range s.alternatives {
+		names[i] = alternative.Name
+	}
+	return names
+}
+
+// Lookup returns the name of the alternative encoded with tag
+func (s *ChoiceSpec) Lookup(tag Tag) (string, bool) {
+	i, ok := s.byTag[choiceTagKey{tag.Class, tag.Number}]
+	if !ok {
+		return "", false
+	}
+	return s.alternatives[i].Name, true
+}
+
+// TagOf returns the tag of the named alternative
+func (s *ChoiceSpec) TagOf(name string) (Tag, bool) {
+	i, ok := s.byName[name]
+	if !ok {
+		return Tag{}, false
+	}
+	return s.alternatives[i].Tag, true
+}
+
+// NewChoice creates a CHOICE holding value as the named alternative,
+// checking that value carries the alternative's tag
+func (s *ChoiceSpec) NewChoice(name string, value ASN1Object) (*ASN1Choice, error) {
+	tag, ok := s.TagOf(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown choice alternative %q", name)
+	}
+	if got := value.Tag(); got.Class != tag.Class || got.Number != tag.Number {
+		return nil, fmt.Errorf("choice alternative %q has tag %s, got %s", name, tag.TagString(), got.TagString())
+	}
+	return NewChoiceWithID(value, name), nil
+}
+
+// Decode decodes a CHOICE from data, dispatching on its tag
+func (s *ChoiceSpec) Decode(data []byte) (*ASN1Choice, int, error) {
+	if len(data) == 0 {
+		return nil, 0, fmt.Errorf("empty data")
+	}
+
+	tag, _, err := DecodeTag(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode tag: %w", err)
+	}
+	i, ok := s.byTag[choiceTagKey{tag.Class, tag.Number}]
+	if !ok {
+		return nil, 0, fmt.Errorf("no choice alternative has tag %s", tag.TagString())
+	}
+	alternative := s.alternatives[i]
+
+	var obj ASN1Object
+	var consumed int
+	if alternative.Decode != nil {
+		obj, consumed, err = alternative.Decode(data)
+	} else {
+		var value *ASN1Value
+		value, consumed, err = DecodeTLV(data)
+		if err == nil {
+			obj = convertToHighLevelObject(value)
+		}
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode alternative %q: %w", alternative.Name, err)
+	}
+	return NewChoiceWithID(obj, alternative.Name), consumed, nil
+}
+
+// choiceSpecs holds the specs registered for use in struct tags
+var choiceSpecs = struct {
+	mu    sync.RWMutex
+	specs map[string]*ChoiceSpec
+}{specs: make(map[string]*ChoiceSpec)}
+
+// RegisterChoiceSpec makes spec available to struct tags as choicespec:NAME.
+// Fields using it hold an ASN1Choice or *ASN1Choice, decode through the spec
+// and are checked against it when marshaling. Registering a name again
+// replaces its spec.
+func RegisterChoiceSpec(name string, spec *ChoiceSpec) error {
+	if name == "" {
+		return fmt.Errorf("choice spec name cannot be empty")
+	}
+	if spec == nil {
+		return fmt.Errorf("choice spec %q cannot be nil", name)
+	}
+
+	choiceSpecs.mu.Lock()
+	defer choiceSpecs.mu.Unlock()
+	choiceSpecs.specs[name] = spec
+	return nil
+}
+
+// lookupChoiceSpec returns the spec registered under name
+func lookupChoiceSpec(name string) (*ChoiceSpec, error) {
+	choiceSpecs.mu.RLock()
+	defer choiceSpecs.mu.RUnlock()
+	spec, ok := choiceSpecs.specs[name]
+	if !ok {
+		return nil, fmt.Errorf("no choice spec registered as %q", name)
+	}
+	return spec, nil
+}
+
+// firstTags returns the tags of the alternatives
+func (s *ChoiceSpec) firstTags() []Tag {
+	tags := make([]Tag, len(s.alternatives))
+	for i, alternative := range s.alternatives {
+		tags[i] = alternative.Tag
+	}
+	return tags
+}
+
+// marshalChoiceSpec encodes a choicespec field, checking its alternative
+func marshalChoiceSpec(v reflect.Value, info *fieldInfo, opts *MarshalOptions) (ASN1Object, error) {
+	spec, err := lookupChoiceSpec(info.ChoiceSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := asASN1Object(v)
+	if !ok {
+		if obj, err = marshalValue(v, opts); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := spec.Lookup(obj.Tag()); !ok {
+		return nil, fmt.Errorf("tag %s is not an alternative of choice spec %q", obj.Tag().TagString(), info.ChoiceSpec)
+	}
+	return obj, nil
+}
+
+// decodeObject is Decode for an element Unmarshal has already decoded within
+// its limits. Only alternatives with their own Decode are decoded again.
+func (s *ChoiceSpec) decodeObject(obj ASN1Object, opts *MarshalOptions) (*ASN1Choice, error) {
+	tag := obj.Tag()
+	i, ok := s.byTag[choiceTagKey{tag.Class, tag.Number}]
+	if !ok {
+		return nil, fmt.Errorf("no choice alternative has tag %s", tag.TagString())
+	}
+	alternative := s.alternatives[i]
+	if alternative.Decode == nil {
+		return NewChoiceWithID(obj, alternative.Name), nil
+	}
+
+	encoded, err := unmarshaledEncoding(obj, opts)
+	if err != nil {
+		return nil, err
+	}
+	value, _, err := alternative.Decode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode alternative %q: %w", alternative.Name, err)
+	}
+	return NewChoiceWithID(value, alternative.Name), nil
+}
+
+// unmarshalChoiceSpec decodes a choicespec field through its spec
+func unmarshalChoiceSpec(obj ASN1Object, v reflect.Value, info *fieldInfo, opts *MarshalOptions) error {
+	spec, err := lookupChoiceSpec(info.ChoiceSpec)
+	if err != nil {
+		return err
+	}
+
+	choice, err := spec.decodeObject(obj, opts)
+	if err != nil {
+		return err
+	}
+
+	switch v.Type() {
+	case reflect.TypeOf(ASN1Choice{}):
+		v.Set(reflect.ValueOf(*choice))
+	case reflect.TypeOf(&ASN1Choice{}):
+		v.Set(reflect.ValueOf(choice))
+	default:
+		return fmt.Errorf("choicespec field must be ASN1Choice or *ASN1Choice, got %v", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package asn1
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func newPartySpec(t *testing.T) *ChoiceSpec {
+	t.Helper()
+	spec, err := NewChoiceSpec(
+		ChoiceSpecAlternative{Name: "name", Tag: NewUniversalTag(TagUTF8String, false)},
+		ChoiceSpecAlternative{Name: "number", Tag: NewContextSpecificTag(0, false), Decode: func(data []byte) (ASN1Object, int, error) {
+			value, consumed, err := DecodeTLV(data)
+			if err != nil {
+				return nil, 0, err
+			}
+			return NewOctetString(value.Value()), consumed, nil
+		}},
+		ChoiceSpecAlternative{Name: "flag", Tag: NewUniversalTag(TagBoolean, false)},
+	)
+	if err != nil {
+		t.Fatalf("NewChoiceSpec() error = %v", err)
+	}
+	return spec
+}
+
+func TestChoiceSpecDecode(t *testing.T) {
+	spec := newPartySpec(t)
+
+	tests := []struct {
+		data []byte
+		id   string
+		want string
+	}{
+		{[]byte{0x0C, 0x02, 'h', 'i'}, "name", "hi"},
+		{[]byte{0x80, 0x02, 0x12, 0x34}, "number", "12 34"},
+		{[]byte{0x01, 0x01, 0xFF}, "flag", "true"},
+	}
+	for _, tt := range tests {
+		choice, consumed, err := spec.Decode(tt.data)
+		if err != nil {
+			t.Fatalf("Decode(%x) error = %v", tt.data, err)
+		}
+		if choice.ChoiceID() != tt.id || consumed != len(tt.data) {
+			t.Errorf("Decode(%x) = %s, %d", tt.data, choice.ChoiceID(), consumed)
+		}
+		if !strings.Contains(choice.Value().String(), tt.want) {
+			t.Errorf("Decode(%x) value = %s, want %s", tt.data, choice.Value(), tt.want)
+		}
+	}
+
+	if _, _, err := spec.Decode([]byte{0x02, 0x01, 0x00}); err == nil {
+		t.Error("Decode() accepted a tag with no alternative")
+	}
+	if name, ok := spec.Lookup(NewContextSpecificTag(0, true)); !ok || name != "number" {
+		t.Errorf("Lookup() = %q, %v", name, ok)
+	}
+	if names := spec.Names(); strings.Join(names, ",") != "name,number,flag" {
+		t.Errorf("Names() = %v", names)
+	}
+}
+
+func TestChoiceSpecValidation(t *testing.T) {
+	_, err := NewChoiceSpec(
+		ChoiceSpecAlternative{Name: "a", Tag: NewContextSpecificTag(1, false)},
+		ChoiceSpecAlternative{Name: "b", Tag: NewContextSpecificTag(1, true)},
+	)
+	if err == nil || !strings.Contains(err.Error(), "share tag") {
+		t.Errorf("NewChoiceSpec() error = %v, want shared tag error", err)
+	}
+
+	spec := newPartySpec(t)
+	if _, err := spec.NewChoice("flag", NewInteger(1)); err == nil {
+		t.Error("NewChoice() accepted a value with the wrong tag")
+	}
+	if choice, err := spec.NewChoice("flag", NewBoolean(true)); err != nil || choice.ChoiceID() != "flag" {
+		t.Errorf("NewChoice() = %v, %v", choice, err)
+	}
+}
+
+func TestChoiceSpecStructTag(t *testing.T) {
+	if err := RegisterChoiceSpec("test.Party", newPartySpec(t)); err != nil {
+		t.Fatalf("RegisterChoiceSpec() error = %v", err)
+	}
+
+	type record struct {
+		Party *ASN1Choice `asn1:"choice,choicespec:test.Party,optional"`
+		Count int64       `asn1:"integer"`
+	}
+
+	encoded, err := Marshal(record{Party: NewChoice(NewUTF8String("hi")), Count: 1})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x07, 0x0C, 0x02, 'h', 'i', 0x02, 0x01, 0x01}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+
+	var decoded record
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Party == nil || decoded.Party.ChoiceID() != "name" || decoded.Count != 1 {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+
+	// The INTEGER is not an alternative, so the optional CHOICE is absent
+	decoded = record{}
+	if err := Unmarshal([]byte{0x30, 0x03, 0x02, 0x01, 0x01}, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Party != nil || decoded.Count != 1 {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+
+	if _, err := Marshal(record{Party: NewChoice(NewInteger(5)), Count: 1}); err == nil {
+		t.Error("Marshal() accepted a value outside the spec")
+	}
+}
+
+func TestChoiceSpecFieldUsesLimits(t *testing.T) {
+	spec, err := NewChoiceSpec(
+		ChoiceSpecAlternative{Name: "nested", Tag: NewUniversalTag(TagSequence, true)},
+		ChoiceSpecAlternative{Name: "flag", Tag: NewUniversalTag(TagBoolean, false)},
+	)
+	if err != nil {
+		t.Fatalf("NewChoiceSpec() error = %v", err)
+	}
+	if err := RegisterChoiceSpec("test.Nested", spec); err != nil {
+		t.Fatalf("RegisterChoiceSpec() error = %v", err)
+	}
+
+	type record struct {
+		Value ASN1Choice `asn1:"choice,choicespec:test.Nested"`
+	}
+	inner := nestedSequences(300)
+	data := append(encodeTagLength(0x30, len(inner)), inner...)
+
+	// Deeper than the default limit, but within the caller's
+	opts := DefaultMarshalOptions()
+	opts.Limits = &DecodeOptions{MaxDepth: 1000}
+	var decoded record
+	if err := UnmarshalWithOptions(data, &decoded, opts); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+	if _, ok := decoded.Value.Value().(*ASN1Structured); !ok || decoded.Value.ChoiceID() != "nested" {
+		t.Errorf("decoded %s %T, want nested *ASN1Structured", decoded.Value.ChoiceID(), decoded.Value.Value())
+	}
+
+	opts.Limits = &DecodeOptions{MaxDepth: 100}
+	err = UnmarshalWithOptions(data, &decoded, opts)
+	expectLimitError(t, err, "MaxDepth")
+}
@@ -0,0 +1,625 @@
+package asn1
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Date is a civil calendar date without a time of day or time zone
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date on which t occurs in t's location
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// Time returns midnight at the start of the date in the given location
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Valid reports whether the date exists in the proleptic Gregorian calendar
+func (d Date) Valid() bool {
+	if d.Year < 0 || d.Year > 9999 {
+		return false
+	}
+	_, err := makeTime(d.Year, int(d.Month), d.Day, 0, 0, 0, time.UTC)
+	return err == nil
+}
+
+// String returns the date in YYYY-MM-DD form
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// TimeOfDay is a civil time of day without a date or time zone
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+// TimeOfDayOf returns the time of day of t in t's location
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second()}
+}
+
+// Valid reports whether the time of day is in range
+func (t TimeOfDay) Valid() bool {
+	return t.Hour >= 0 && t.Hour <= 23 && t.Minute >= 0 && t.Minute <= 59 && t.Second >= 0 && t.Second <= 59
+}
+
+// String returns the time of day in HH:MM:SS form
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+}
+
+// ASN1Date represents an ASN.1 DATE value
+type ASN1Date struct {
+	date Date
+}
+
+// NewDate creates a new DATE
+func NewDate(d Date) *ASN1Date {
+	return &ASN1Date{date: d}
+}
+
+// Date returns the date value
+func (d *ASN1Date) Date() Date {
+	return d.date
+}
+
+// Tag returns the ASN.1 tag for DATE
+func (d *ASN1Date) Tag() Tag {
+	return NewUniversalTag(TagDate, false)
+}
+
+// Encode returns the BER encoding of the DATE (YYYYMMDD)
+func (d *ASN1Date) Encode() ([]byte, error) {
+	if !d.date.Valid() {
+		return nil, fmt.Errorf("invalid DATE: %s", d.date)
+	}
+	value := fmt.Sprintf("%04d%02d%02d", d.date.Year, int(d.date.Month), d.date.Day)
+	return EncodeTLV(d.Tag(), []byte(value))
+}
+
+// String returns a string representation of the DATE
+func (d *ASN1Date) String() string {
+	return fmt.Sprintf("DATE{%s}", d.date)
+}
+
+// TaggedString returns a string representation with tag information
+func (d *ASN1Date) TaggedString() string {
+	return fmt.Sprintf("%s DATE: %s", d.Tag().TagString(), d.date)
+}
+
+// ASN1TimeOfDay represents an ASN.1 TIME-OF-DAY value
+type ASN1TimeOfDay struct {
+	timeOfDay TimeOfDay
+}
+
+// NewTimeOfDay creates a new TIME-OF-DAY
+func NewTimeOfDay(t TimeOfDay) *ASN1TimeOfDay {
+	return &ASN1TimeOfDay{timeOfDay: t}
+}
+
+// TimeOfDay returns the time of day value
+func (t *ASN1TimeOfDay) TimeOfDay() TimeOfDay {
+	return t.timeOfDay
+}
+
+// Tag returns the ASN.1 tag for TIME-OF-DAY
+func (t *ASN1TimeOfDay) Tag() Tag {
+	return NewUniversalTag(TagTimeOfDay, false)
+}
+
+// Encode returns the BER encoding of the TIME-OF-DAY (HHMMSS)
+func (t *ASN1TimeOfDay) Encode() ([]byte, error) {
+	if !t.timeOfDay.Valid() {
+		return nil, fmt.Errorf("invalid TIME-OF-DAY: %s", t.timeOfDay)
+	}
+	value := fmt.Sprintf("%02d%02d%02d", t.timeOfDay.Hour, t.timeOfDay.Minute, t.timeOfDay.Second)
+	return EncodeTLV(t.Tag(), []byte(value))
+}
+
+// String returns a string representation of the TIME-OF-DAY
+func (t *ASN1TimeOfDay) String() string {
+	return fmt.Sprintf("TIME-OF-DAY{%s}", t.timeOfDay)
+}
+
+// TaggedString returns a string representation with tag information
+func (t *ASN1TimeOfDay) TaggedString() string {
+	return fmt.Sprintf("%s TIME-OF-DAY: %s", t.Tag().TagString(), t.timeOfDay)
+}
+
+// ASN1DateTime represents an ASN.1 DATE-TIME value.
+// DATE-TIME carries no time zone; the date and time are taken from the time's own location.
+type ASN1DateTime struct {
+	time time.Time
+}
+
+// NewDateTime creates a new DATE-TIME
+func NewDateTime(t time.Time) *ASN1DateTime {
+	return &ASN1DateTime{time: t}
+}
+
+// Time returns the time value
+func (d *ASN1DateTime) Time() time.Time {
+	return d.time
+}
+
+// Tag returns the ASN.1 tag for DATE-TIME
+func (d *ASN1DateTime) Tag() Tag {
+	return NewUniversalTag(TagDateTime, false)
+}
+
+// Encode returns the BER encoding of the DATE-TIME (YYYYMMDDHHMMSS)
+func (d *ASN1DateTime) Encode() ([]byte, error) {
+	if d.time.Year() < 0 || d.time.Year() > 9999 {
+		return nil, fmt.Errorf("DATE-TIME cannot represent year %d", d.time.Year())
+	}
+	return EncodeTLV(d.Tag(), []byte(d.time.Format("20060102150405")))
+}
+
+// String returns a string representation of the DATE-TIME
+func (d *ASN1DateTime) String() string {
+	return fmt.Sprintf("DATE-TIME{%s}", d.time.Format("2006-01-02T15:04:05"))
+}
+
+// TaggedString returns a string representation with tag information
+func (d *ASN1DateTime) TaggedString() string {
+	return fmt.Sprintf("%s DATE-TIME: %s", d.Tag().TagString(), d.time.Format("2006-01-02T15:04:05"))
+}
+
+// ASN1Duration represents an ASN.1 DURATION value
+type ASN1Duration struct {
+	duration time.Duration
+}
+
+// NewDuration creates a new DURATION
+func NewDuration(d time.Duration) *ASN1Duration {
+	return &ASN1Duration{duration: d}
+}
+
+// Duration returns the duration value
+func (d *ASN1Duration) Duration() time.Duration {
+	return d.duration
+}
+
+// Tag returns the ASN.1 tag for DURATION
+func (d *ASN1Duration) Tag() Tag {
+	return NewUniversalTag(TagDuration, false)
+}
+
+// Encode returns the BER encoding of the DURATION in ISO 8601 form (for example PT1H30M0.5S)
+func (d *ASN1Duration) Encode() ([]byte, error) {
+	if d.duration < 0 {
+		return nil, fmt.Errorf("DURATION cannot be negative: %v", d.duration)
+	}
+	return EncodeTLV(d.Tag(), []byte(formatISODuration(d.duration)))
+}
+
+// String returns a string representation of the DURATION
+func (d *ASN1Duration) String() string {
+	return fmt.Sprintf("DURATION{%s}", formatISODuration(d.duration))
+}
+
+// TaggedString returns a string representation with tag information
+func (d *ASN1Duration) TaggedString() string {
+	return fmt.Sprintf("%s DURATION: %s", d.Tag().TagString(), formatISODuration(d.duration))
+}
+
+// ASN1Time represents the general ASN.1 TIME type, which holds any ISO 8601
+// time, date, interval or recurrence as a string
+type ASN1Time struct {
+	value string
+}
+
+// NewTime creates a new TIME from its ISO 8601 string form
+func NewTime(value string) *ASN1Time {
+	return &ASN1Time{value: value}
+}
+
+// Value returns the ISO 8601 string
+func (t *ASN1Time) Value() string {
+	return t.value
+}
+
+// Time interprets the value as a date, date-time or time with zone and returns it as a time.Time.
+// Intervals, recurrences and durations cannot be converted and return an error.
+func (t *ASN1Time) Time() (time.Time, error) {
+	layouts := []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+		"20060102T150405.999999999Z0700",
+		"20060102T150405.999999999",
+		"2006-01-02",
+		"20060102",
+	}
+	for _, layout := range layouts {
+		if parsed, err := time.ParseInLocation(layout, t.value, time.Local); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("TIME value %q is not a supported date or time", t.value)
+}
+
+// Tag returns the ASN.1 tag for TIME
+func (t *ASN1Time) Tag() Tag {
+	return NewUniversalTag(TagTime, false)
+}
+
+// Encode returns the BER encoding of the TIME
+func (t *ASN1Time) Encode() ([]byte, error) {
+	if t.value == "" || !isVisibleString(t.value) {
+		return nil, fmt.Errorf("invalid TIME value: %q", t.value)
+	}
+	return EncodeTLV(t.Tag(), []byte(t.value))
+}
+
+// String returns a string representation of the TIME
+func (t *ASN1Time) String() string {
+	return fmt.Sprintf("TIME{%s}", t.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (t *ASN1Time) TaggedString() string {
+	return fmt.Sprintf("%s TIME: %s", t.Tag().TagString(), t.value)
+}
+
+// DecodeDateValue decodes DATE content octets in basic (YYYYMMDD) or extended (YYYY-MM-DD) form
+func DecodeDateValue(data []byte) (Date, error) {
+	s, err := basicForm(string(data), '-', 4, 7)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid DATE: %w", err)
+	}
+	year, rest, err := parseTimeDigits(s, 4, "year")
+	if err != nil {
+		return Date{}, err
+	}
+	month, rest, err := parseTimeDigits(rest, 2, "month")
+	if err != nil {
+		return Date{}, err
+	}
+	day, rest, err := parseTimeDigits(rest, 2, "day")
+	if err != nil {
+		return Date{}, err
+	}
+	if rest != "" {
+		return Date{}, fmt.Errorf("trailing data in DATE: %q", rest)
+	}
+
+	date := Date{Year: year, Month: time.Month(month), Day: day}
+	if !date.Valid() {
+		return Date{}, fmt.Errorf("invalid DATE: %s", date)
+	}
+	return date, nil
+}
+
+// DecodeTimeOfDayValue decodes TIME-OF-DAY content octets in basic (HHMMSS) or extended (HH:MM:SS) form
+func DecodeTimeOfDayValue(data []byte) (TimeOfDay, error) {
+	s, err := basicForm(string(data), ':', 2, 5)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("invalid TIME-OF-DAY: %w", err)
+	}
+	hour, rest, err := parseTimeDigits(s, 2, "hour")
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	minute, rest, err := parseTimeDigits(rest, 2, "minute")
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	second, rest, err := parseTimeDigits(rest, 2, "second")
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	if rest != "" {
+		return TimeOfDay{}, fmt.Errorf("trailing data in TIME-OF-DAY: %q", rest)
+	}
+
+	timeOfDay := TimeOfDay{Hour: hour, Minute: minute, Second: second}
+	if !timeOfDay.Valid() {
+		return TimeOfDay{}, fmt.Errorf("invalid TIME-OF-DAY: %s", timeOfDay)
+	}
+	return timeOfDay, nil
+}
+
+// DecodeDateTimeValue decodes DATE-TIME content octets in basic (YYYYMMDD[T]HHMMSS) or
+// extended (YYYY-MM-DDTHH:MM:SS) form. The result is a local time.
+func DecodeDateTimeValue(data []byte) (time.Time, error) {
+	s := string(data)
+	var datePart, timePart string
+	switch {
+	case len(s) == 19 && s[10] == 'T':
+		datePart, timePart = s[:10], s[11:]
+	case len(s) == 15 && s[8] == 'T':
+		datePart, timePart = s[:8], s[9:]
+	case len(s) == 14:
+		datePart, timePart = s[:8], s[8:]
+	default:
+		return time.Time{}, fmt.Errorf("invalid DATE-TIME: %q", s)
+	}
+
+	date, err := DecodeDateValue([]byte(datePart))
+	if err != nil {
+		return time.Time{}, err
+	}
+	timeOfDay, err := DecodeTimeOfDayValue([]byte(timePart))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(date.Year, date.Month, date.Day, timeOfDay.Hour, timeOfDay.Minute, timeOfDay.Second, 0, time.Local), nil
+}
+
+// basicForm removes the separators of an extended form value, which must be
+// at exactly the given positions. Values without separators are returned as is.
+func basicForm(s string, separator byte, positions ...int) (string, error) {
+	if strings.IndexByte(s, separator) < 0 {
+		return s, nil
+	}
+	basic := []byte(s)
+	for i := len(positions) - 1; i >= 0; i-- {
+		position := positions[i]
+		if position >= len(basic) || basic[position] != separator {
+			return "", fmt.Errorf("misplaced %q in %q", separator, s)
+		}
+		basic = append(basic[:position], basic[position+1:]...)
+	}
+	if strings.IndexByte(string(basic), separator) >= 0 {
+		return "", fmt.Errorf("misplaced %q in %q", separator, s)
+	}
+	return string(basic), nil
+}
+
+// DecodeDurationValue decodes DURATION content octets in ISO 8601 form (PnYnMnWnDTnHnMnS).
+// Years and months have no fixed length and are rejected unless zero.
+func DecodeDurationValue(data []byte) (time.Duration, error) {
+	s := string(data)
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("invalid DURATION: %q", s)
+	}
+	s = s[1:]
+
+	var total time.Duration
+	inTime := false
+	lastFraction := false
+	components := 0
+	componentsBeforeT := 0
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if inTime {
+				return 0, fmt.Errorf("invalid DURATION: repeated T designator")
+			}
+			inTime = true
+			componentsBeforeT = components
+			s = s[1:]
+			continue
+		}
+		if lastFraction {
+			return 0, fmt.Errorf("invalid DURATION: only the last component may have a fraction")
+		}
+
+		// Number with optional fraction
+		digits := 0
+		for digits < len(s) && isDigit(s[digits]) {
+			digits++
+		}
+		if digits == 0 {
+			return 0, fmt.Errorf("invalid DURATION: expected number at %q", s)
+		}
+		whole := s[:digits]
+		s = s[digits:]
+		fraction := ""
+		if len(s) > 0 && (s[0] == '.' || s[0] == ',') {
+			end := 1
+			for end < len(s) && isDigit(s[end]) {
+				end++
+			}
+			if end == 1 {
+				return 0, fmt.Errorf("invalid DURATION: missing fraction digits")
+			}
+			fraction = s[1:end]
+			s = s[end:]
+			lastFraction = true
+		}
+		if len(s) == 0 {
+			return 0, fmt.Errorf("invalid DURATION: missing designator after %s", whole)
+		}
+
+		var unit time.Duration
+		switch designator := s[0]; {
+		case !inTime && designator == 'Y', !inTime && designator == 'M':
+			if strings.Trim(whole, "0") != "" || strings.Trim(fraction, "0") != "" {
+				return 0, fmt.Errorf("DURATION with years or months cannot be represented as time.Duration")
+			}
+		case !inTime && designator == 'W':
+			unit = 7 * 24 * time.Hour
+		case !inTime && designator == 'D':
+			unit = 24 * time.Hour
+		case inTime && designator == 'H':
+			unit = time.Hour
+		case inTime && designator == 'M':
+			unit = time.Minute
+		case inTime && designator == 'S':
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("invalid DURATION designator %q", designator)
+		}
+		s = s[1:]
+		components++
+
+		if unit == 0 {
+			continue
+		}
+		var n int64
+		for i := 0; i < len(whole); i++ {
+			digit := int64(whole[i] - '0')
+			if n > (math.MaxInt64-digit)/10 {
+				return 0, fmt.Errorf("DURATION component too large: %s", whole)
+			}
+			n = n*10 + digit
+		}
+		if n > math.MaxInt64/int64(unit) {
+			return 0, fmt.Errorf("DURATION too large for time.Duration")
+		}
+		part := time.Duration(n) * unit
+
+		// The fraction adds less than one unit, but may still overflow
+		var fractionPart time.Duration
+		scale := unit
+		for i := 0; i < len(fraction); i++ {
+			scale /= 10
+			fractionPart += time.Duration(fraction[i]-'0') * scale
+		}
+		if part > math.MaxInt64-fractionPart {
+			return 0, fmt.Errorf("DURATION too large for time.Duration")
+		}
+		part += fractionPart
+		if total > math.MaxInt64-part {
+			return 0, fmt.Errorf("DURATION too large for time.Duration")
+		}
+		total += part
+	}
+
+	if components == 0 || (inTime && components == componentsBeforeT) {
+		return 0, fmt.Errorf("invalid DURATION: missing components")
+	}
+	return total, nil
+}
+
+// formatISODuration formats a non-negative duration as an ISO 8601 time duration
+func formatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if hours := d / time.Hour; hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+		d -= minutes * time.Minute
+	}
+	if d > 0 {
+		seconds := d / time.Second
+		nanos := d - seconds*time.Second
+		if nanos == 0 {
+			fmt.Fprintf(&b, "%dS", seconds)
+		} else {
+			fraction := strings.TrimRight(fmt.Sprintf("%09d", nanos), "0")
+			fmt.Fprintf(&b, "%d.%sS", seconds, fraction)
+		}
+	}
+	return b.String()
+}
+
+// DecodeDate decodes a DATE from BER data
+func DecodeDate(data []byte) (*ASN1Date, int, error) {
+	value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode TLV: %w", err)
+	}
+
+	expectedTag := NewUniversalTag(TagDate, false)
+	if value.Tag() != expectedTag {
+		return nil, 0, fmt.Errorf("expected DATE tag %+v, got %+v", expectedTag, value.Tag())
+	}
+
+	date, err := DecodeDateValue(value.Value())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse DATE: %w", err)
+	}
+
+	return NewDate(date), consumed, nil
+}
+
+// DecodeTimeOfDay decodes a TIME-OF-DAY from BER data
+func DecodeTimeOfDay(data []byte) (*ASN1TimeOfDay, int, error) {
+	value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode TLV: %w", err)
+	}
+
+	expectedTag := NewUniversalTag(TagTimeOfDay, false)
+	if value.Tag() != expectedTag {
+		return nil, 0, fmt.Errorf("expected TIME-OF-DAY tag %+v, got %+v", expectedTag, value.Tag())
+	}
+
+	timeOfDay, err := DecodeTimeOfDayValue(value.Value())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse TIME-OF-DAY: %w", err)
+	}
+
+	return NewTimeOfDay(timeOfDay), consumed, nil
+}
+
+// DecodeDateTime decodes a DATE-TIME from BER data
+func DecodeDateTime(data []byte) (*ASN1DateTime, int, error) {
+	value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode TLV: %w", err)
+	}
+
+	expectedTag := NewUniversalTag(TagDateTime, false)
+	if value.Tag() != expectedTag {
+		return nil, 0, fmt.Errorf("expected DATE-TIME tag %+v, got %+v", expectedTag, value.Tag())
+	}
+
+	dateTime, err := DecodeDateTimeValue(value.Value())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse DATE-TIME: %w", err)
+	}
+
+	return NewDateTime(dateTime), consumed, nil
+}
+
+// DecodeDuration decodes a DURATION from BER data
+func DecodeDuration(data []byte) (*ASN1Duration, int, error) {
+	value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode TLV: %w", err)
+	}
+
+	expectedTag := NewUniversalTag(TagDuration, false)
+	if value.Tag() != expectedTag {
+		return nil, 0, fmt.Errorf("expected DURATION tag %+v, got %+v", expectedTag, value.Tag())
+	}
+
+	duration, err := DecodeDurationValue(value.Value())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse DURATION: %w", err)
+	}
+
+	return NewDuration(duration), consumed, nil
+}
+
+// DecodeTime decodes a TIME from BER data
+func DecodeTime(data []byte) (*ASN1Time, int, error) {
+	value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode TLV: %w", err)
+	}
+
+	expectedTag := NewUniversalTag(TagTime, false)
+	if value.Tag() != expectedTag {
+		return nil, 0, fmt.Errorf("expected TIME tag %+v, got %+v", expectedTag, value.Tag())
+	}
+
+	str := string(value.Value())
+	if str == "" || !isVisibleString(str) {
+		return nil, 0, fmt.Errorf("invalid TIME value: %q", str)
+	}
+
+	return NewTime(str), consumed, nil
+}
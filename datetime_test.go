@@ -0,0 +1,167 @@
+package asn1
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDateTimeTypesEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  ASN1Object
+		want []byte
+	}{
+		{"DATE", NewDate(Date{2024, time.February, 29}), append([]byte{0x1F, 0x1F, 0x08}, "20240229"...)},
+		{"TIME-OF-DAY", NewTimeOfDay(TimeOfDay{23, 5, 9}), append([]byte{0x1F, 0x20, 0x06}, "230509"...)},
+		{"DATE-TIME", NewDateTime(time.Date(2024, 3, 1, 12, 0, 30, 0, time.UTC)), append([]byte{0x1F, 0x21, 0x0E}, "20240301120030"...)},
+		{"DURATION", NewDuration(90*time.Minute + 500*time.Millisecond), append([]byte{0x1F, 0x22, 0x0B}, "PT1H30M0.5S"...)},
+		{"TIME", NewTime("2024-03-01T12:00:00Z"), append([]byte{0x0E, 0x14}, "2024-03-01T12:00:00Z"...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.obj.Encode()
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(encoded, tt.want) {
+				t.Errorf("Encode() = %x, want %x", encoded, tt.want)
+			}
+
+			value, consumed, err := DecodeTLV(encoded)
+			if err != nil {
+				t.Fatalf("DecodeTLV() error = %v", err)
+			}
+			if consumed != len(encoded) {
+				t.Errorf("DecodeTLV() consumed = %d, want %d", consumed, len(encoded))
+			}
+			decoded := convertPrimitiveValue(value)
+			if decoded.String() != tt.obj.String() {
+				t.Errorf("decoded = %s, want %s", decoded.String(), tt.obj.String())
+			}
+		})
+	}
+}
+
+func TestDateTimeTypeDecoders(t *testing.T) {
+	date, _, err := DecodeDate(append([]byte{0x1F, 0x1F, 0x0A}, "2023-12-25"...))
+	if err != nil {
+		t.Fatalf("DecodeDate() error = %v", err)
+	}
+	if date.Date() != (Date{2023, time.December, 25}) {
+		t.Errorf("DecodeDate() = %v", date.Date())
+	}
+
+	timeOfDay, _, err := DecodeTimeOfDay(append([]byte{0x1F, 0x20, 0x08}, "07:15:00"...))
+	if err != nil {
+		t.Fatalf("DecodeTimeOfDay() error = %v", err)
+	}
+	if timeOfDay.TimeOfDay() != (TimeOfDay{7, 15, 0}) {
+		t.Errorf("DecodeTimeOfDay() = %v", timeOfDay.TimeOfDay())
+	}
+
+	dateTime, _, err := DecodeDateTime(append([]byte{0x1F, 0x21, 0x13}, "2023-12-25T07:15:00"...))
+	if err != nil {
+		t.Fatalf("DecodeDateTime() error = %v", err)
+	}
+	if !dateTime.Time().Equal(time.Date(2023, 12, 25, 7, 15, 0, 0, time.Local)) {
+		t.Errorf("DecodeDateTime() = %v", dateTime.Time())
+	}
+
+	durations := map[string]time.Duration{
+		"PT0S":       0,
+		"P1D":        24 * time.Hour,
+		"P1W":        7 * 24 * time.Hour,
+		"P0Y0M1DT2H": 26 * time.Hour,
+		"PT1.5H":     90 * time.Minute,
+		"PT2M3,25S":  2*time.Minute + 3250*time.Millisecond,
+
+		"PT9223372036.854775807S": math.MaxInt64,
+	}
+	for input, want := range durations {
+		got, err := DecodeDurationValue([]byte(input))
+		if err != nil {
+			t.Errorf("DecodeDurationValue(%q) error = %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("DecodeDurationValue(%q) = %v, want %v", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"", "P", "PT", "P1DT", "P1Y", "P2M", "PT1.5H2M", "PT5", "P5H", "PTT1S", "1D",
+		"P99999999999D", "PT999999999999H", "PT9223372036.9S", "PT99999999999999999999S", "P15250W2D"} {
+		if got, err := DecodeDurationValue([]byte(input)); err == nil {
+			t.Errorf("DecodeDurationValue(%q) = %v, expected error", input, got)
+		}
+	}
+
+	for _, input := range []string{"20230230", "2023-13-01", "202312251", "2-0-2-4-0-1-0-1", "2024-0101", "202401-01", "2024-01-01-"} {
+		if _, err := DecodeDateValue([]byte(input)); err == nil {
+			t.Errorf("DecodeDateValue(%q) expected error", input)
+		}
+	}
+	for _, input := range []string{"246000", "1:2:3:4", "12:3000", "1230:00", "12:30:0:0"} {
+		if _, err := DecodeTimeOfDayValue([]byte(input)); err == nil {
+			t.Errorf("DecodeTimeOfDayValue(%q) expected error", input)
+		}
+	}
+	for _, input := range []string{"2024-01-01T120000", "20240101T12:00:00", "2024010112:00:0T", "2-0-2-4-0-1-0-1T120000"} {
+		if _, err := DecodeDateTimeValue([]byte(input)); err == nil {
+			t.Errorf("DecodeDateTimeValue(%q) expected error", input)
+		}
+	}
+	for _, input := range []string{"20240101T120000", "20240101120000", "2024-01-01T12:00:00"} {
+		if _, err := DecodeDateTimeValue([]byte(input)); err != nil {
+			t.Errorf("DecodeDateTimeValue(%q) error = %v", input, err)
+		}
+	}
+	if _, err := NewDuration(-time.Second).Encode(); err == nil {
+		t.Error("expected error encoding negative DURATION")
+	}
+}
+
+type Schedule struct {
+	Day      Date          `asn1:"date"`
+	Opens    TimeOfDay     `asn1:"timeofday"`
+	Updated  time.Time     `asn1:"datetime"`
+	Interval time.Duration `asn1:"duration"`
+	Spec     string        `asn1:"time"`
+	Holiday  *Date         `asn1:"date,optional,tag:0"`
+	Started  time.Time     `asn1:"date"`
+}
+
+func TestDateTimeTypesMarshaling(t *testing.T) {
+	holiday := Date{2024, time.December, 25}
+	original := &Schedule{
+		Day:      Date{2024, time.June, 1},
+		Opens:    TimeOfDay{9, 30, 0},
+		Updated:  time.Date(2024, 5, 31, 18, 45, 10, 0, time.Local),
+		Interval: 15 * time.Minute,
+		Spec:     "R/2024-01-01T00:00:00Z/P1D",
+		Holiday:  &holiday,
+		Started:  time.Date(2020, 1, 2, 0, 0, 0, 0, time.Local),
+	}
+
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded Schedule
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded.Day != original.Day || decoded.Opens != original.Opens || decoded.Interval != original.Interval || decoded.Spec != original.Spec {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", decoded, *original)
+	}
+	if !decoded.Updated.Equal(original.Updated) || !decoded.Started.Equal(original.Started) {
+		t.Errorf("time mismatch: got %v/%v, want %v/%v", decoded.Updated, decoded.Started, original.Updated, original.Started)
+	}
+	if decoded.Holiday == nil || *decoded.Holiday != holiday {
+		t.Errorf("Holiday = %v, want %v", decoded.Holiday, holiday)
+	}
+}
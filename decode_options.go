@@ -0,0 +1,176 @@
+package asn1
+
+// DecodeOptions bounds the resources used to decode untrusted input.
+// A zero field means no limit.
+type DecodeOptions struct {
+	// MaxDepth is the maximum nesting of elements; the outermost is at depth 1
+	MaxDepth int
+	// MaxElements is the maximum number of elements in the input
+	MaxElements int
+	// MaxAllocation is the maximum number of bytes allocated for the copy of
+	// the input and the content of its primitive elements
+	MaxAllocation int
+	// MaxPrimitiveLength is the maximum content length of a primitive element
+	MaxPrimitiveLength int
+	// MaxLength is the maximum content length of any element, primitive or
+	// constructed. Lengths are limited to the size of an int regardless.
+	MaxLength int
+	// Strict makes a malformed element anywhere in the input an error. By
+	// default only the elements directly inside the outermost one must be
+	// well formed; a constructed element below them whose content does not
+	// parse is kept as an undecoded *ASN1Value.
+	Strict bool
+}
+
+// DefaultDecodeOptions returns the limits used when none are given. Only the
+// nesting depth is limited, which keeps decoding off the end of the stack;
+// the element count and allocations are proportional to the input size.
+func DefaultDecodeOptions() *DecodeOptions {
+	return &DecodeOptions{MaxDepth: 256}
+}
+
+// DecodeWithOptions decodes the first element of data and everything nested
+// in it into high-level objects, returning the number of bytes consumed.
+// Input exceeding opts fails with a *LimitError; nil opts means
+// DefaultDecodeOptions.
+func DecodeWithOptions(data []byte, opts *DecodeOptions) (ASN1Object, int, error) {
+	return newDecodeState(opts).decode(data)
+}
+
+// decodeState tracks the resources used while decoding one input
+type decodeState struct {
+	limits    *DecodeOptions
+	elements  int
+	allocated int
+	offsets   map[ASN1Object]int // input offsets of the objects created, if not nil
+	input     []byte             // the copy of the input the objects share
+}
+
+// newDecodeState creates a decodeState enforcing limits, or DefaultDecodeOptions if nil
+func newDecodeState(limits *DecodeOptions) *decodeState {
+	if limits == nil {
+		limits = DefaultDecodeOptions()
+	}
+	return &decodeState{limits: limits}
+}
+
+// decode decodes the first element of data. The input is copied once; the
+// objects created share the copy.
+func (s *decodeState) decode(data []byte) (ASN1Object, int, error) {
+	val, consumed, err := decodeTLV(data)
+	if err != nil {
+		return nil, 0, &SyntaxError{Offset: 0, Msg: "failed to decode ASN.1 data", Err: err}
+	}
+	if err := s.checkLength(val, 0); err != nil {
+		return nil, 0, err
+	}
+	if err := s.allocate(consumed, 0); err != nil {
+		return nil, 0, err
+	}
+
+	raw := make([]byte, consumed)
+	copy(raw, data[:consumed])
+	val.value = raw[consumed-len(val.value):]
+	val.raw = raw
+	s.input = raw
+
+	obj, err := s.convert(val, 0, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	return obj, consumed, nil
+}
+
+// convert converts val, found at offset at and nesting depth depth, and the
+// elements nested in it to high-level objects
+func (s *decodeState) convert(val *ASN1Value, at, depth int) (ASN1Object, error) {
+	if s.limits.MaxDepth > 0 && depth > s.limits.MaxDepth {
+		return nil, &LimitError{Offset: at, Limit: "MaxDepth", Max: s.limits.MaxDepth}
+	}
+	s.elements++
+	if s.limits.MaxElements > 0 && s.elements > s.limits.MaxElements {
+		return nil, &LimitError{Offset: at, Limit: "MaxElements", Max: s.limits.MaxElements}
+	}
+
+	content := val.Value()
+	var obj ASN1Object
+	if val.Tag().Constructed {
+		structured := NewStructured(val.Tag())
+		header := len(val.raw) - len(content)
+		offset := 0
+
+		for offset < len(content) {
+			elementValue, consumed, err := decodeTLV(content[offset:])
+			if err != nil {
+				if s.limits.Strict || depth == 1 {
+					return nil, &SyntaxError{Offset: at + header + offset, Msg: "failed to decode element", Err: err}
+				}
+				// If we can't parse the content, keep the ASN1Value
+				obj = val
+				break
+			}
+			if err := s.checkLength(elementValue, at+header+offset); err != nil {
+				return nil, err
+			}
+
+			element, err := s.convert(elementValue, at+header+offset, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			structured.Add(element)
+			offset += consumed
+		}
+
+		if obj == nil {
+			structured.raw = val.raw
+			obj = convertUniversalStructured(val, structured)
+		}
+	} else {
+		if s.limits.MaxPrimitiveLength > 0 && len(content) > s.limits.MaxPrimitiveLength {
+			return nil, &LimitError{Offset: at, Limit: "MaxPrimitiveLength", Max: s.limits.MaxPrimitiveLength}
+		}
+		if err := s.allocate(len(content), at); err != nil {
+			return nil, err
+		}
+		obj = convertPrimitiveValue(val)
+	}
+
+	if s.offsets != nil {
+		s.offsets[obj] = at
+	}
+	return obj, nil
+}
+
+// encoding returns the bytes obj was decoded from, if it was created from
+// this input and offsets are recorded. Primitive objects do not keep them.
+func (s *decodeState) encoding(obj ASN1Object) []byte {
+	if s == nil || s.offsets == nil {
+		return nil
+	}
+	at, ok := s.offsets[obj]
+	if !ok {
+		return nil
+	}
+	_, consumed, err := decodeTLV(s.input[at:])
+	if err != nil {
+		return nil
+	}
+	return s.input[at : at+consumed]
+}
+
+// checkLength checks the content length of the element val at offset at
+func (s *decodeState) checkLength(val *ASN1Value, at int) error {
+	if s.limits.MaxLength > 0 && len(val.value) > s.limits.MaxLength {
+		return &LimitError{Offset: at, Limit: "MaxLength", Max: s.limits.MaxLength}
+	}
+	return nil
+}
+
+// allocate accounts for n bytes allocated while decoding the element at offset at
+func (s *decodeState) allocate(n, at int) error {
+	s.allocated += n
+	if s.limits.MaxAllocation > 0 && s.allocated > s.limits.MaxAllocation {
+		return &LimitError{Offset: at, Limit: "MaxAllocation", Max: s.limits.MaxAllocation}
+	}
+	return nil
+}
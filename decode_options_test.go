@@ -0,0 +1,160 @@
+package asn1
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// nestedSequences returns depth SEQUENCEs nested in each other around a NULL
+func nestedSequences(depth int) []byte {
+	data := []byte{0x05, 0x00}
+	for i := 0; i < depth; i++ {
+		data = append(encodeTagLength(0x30, len(data)), data...)
+	}
+	return data
+}
+
+func encodeTagLength(tag byte, length int) []byte {
+	encoded, _ := EncodeLength(length)
+	return append([]byte{tag}, encoded...)
+}
+
+func expectLimitError(t *testing.T, err error, limit string) *LimitError {
+	t.Helper()
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("expected *LimitError, got %T: %v", err, err)
+	}
+	if limitErr.Limit != limit {
+		t.Errorf("expected limit %s, got %s", limit, limitErr.Limit)
+	}
+	return limitErr
+}
+
+func TestDecodeWithOptionsMaxDepth(t *testing.T) {
+	data := nestedSequences(10)
+
+	if _, _, err := DecodeWithOptions(data, &DecodeOptions{MaxDepth: 11}); err != nil {
+		t.Fatalf("unexpected error at the limit: %v", err)
+	}
+
+	_, _, err := DecodeWithOptions(data, &DecodeOptions{MaxDepth: 5})
+	limitErr := expectLimitError(t, err, "MaxDepth")
+	if limitErr.Offset != 10 {
+		t.Errorf("expected offset 10, got %d", limitErr.Offset)
+	}
+}
+
+func TestDecodeDefaultDepthLimit(t *testing.T) {
+	data := nestedSequences(1000)
+
+	_, _, err := DecodeWithOptions(data, nil)
+	expectLimitError(t, err, "MaxDepth")
+
+	var v struct {
+		Inner RawValue `asn1:"any"`
+	}
+	err = Unmarshal(data, &v)
+	expectLimitError(t, err, "MaxDepth")
+}
+
+func TestDecodeWithOptionsMaxElements(t *testing.T) {
+	// SEQUENCE OF 100 NULLs
+	content := bytes.Repeat([]byte{0x05, 0x00}, 100)
+	data := append(encodeTagLength(0x30, len(content)), content...)
+
+	obj, _, err := DecodeWithOptions(data, &DecodeOptions{MaxElements: 101})
+	if err != nil {
+		t.Fatalf("unexpected error at the limit: %v", err)
+	}
+	if got := len(obj.(*ASN1Structured).Elements()); got != 100 {
+		t.Errorf("expected 100 elements, got %d", got)
+	}
+
+	_, _, err = DecodeWithOptions(data, &DecodeOptions{MaxElements: 50})
+	expectLimitError(t, err, "MaxElements")
+}
+
+func TestDecodeWithOptionsMaxPrimitiveLength(t *testing.T) {
+	data := append(encodeTagLength(0x04, 1000), make([]byte, 1000)...)
+
+	_, _, err := DecodeWithOptions(data, &DecodeOptions{MaxPrimitiveLength: 999})
+	expectLimitError(t, err, "MaxPrimitiveLength")
+
+	if _, _, err := DecodeWithOptions(data, &DecodeOptions{MaxPrimitiveLength: 1000}); err != nil {
+		t.Errorf("unexpected error at the limit: %v", err)
+	}
+}
+
+func TestDecodeWithOptionsMaxAllocation(t *testing.T) {
+	data := append(encodeTagLength(0x04, 1000), make([]byte, 1000)...)
+
+	_, _, err := DecodeWithOptions(data, &DecodeOptions{MaxAllocation: 512})
+	expectLimitError(t, err, "MaxAllocation")
+
+	if _, _, err := DecodeWithOptions(data, &DecodeOptions{MaxAllocation: 4096}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUnmarshalLimits(t *testing.T) {
+	type record struct {
+		Name  string `asn1:"utf8string"`
+		Count int64  `asn1:"integer"`
+	}
+	data, err := Marshal(record{Name: "a long enough name", Count: 7})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	opts := DefaultMarshalOptions()
+	opts.Limits = &DecodeOptions{MaxPrimitiveLength: 8}
+	var got record
+	err = UnmarshalWithOptions(data, &got, opts)
+	limitErr := expectLimitError(t, err, "MaxPrimitiveLength")
+	if limitErr.Offset != 2 {
+		t.Errorf("expected offset 2, got %d", limitErr.Offset)
+	}
+
+	opts.Limits = &DecodeOptions{MaxPrimitiveLength: 32}
+	if err := UnmarshalWithOptions(data, &got, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != "a long enough name" || got.Count != 7 {
+		t.Errorf("unexpected result %+v", got)
+	}
+}
+
+func TestDecodeOptionsStrict(t *testing.T) {
+	// SEQUENCE { INTEGER 1, SEQUENCE { SEQUENCE { OCTET STRING truncated } } }
+	data := []byte{0x30, 0x09, 0x02, 0x01, 0x01, 0x30, 0x04, 0x30, 0x02, 0x04, 0x05}
+	type record struct {
+		ID    int64    `asn1:"integer"`
+		Inner RawValue `asn1:"any"`
+	}
+
+	// By default the malformed element is kept undecoded
+	var got record
+	if err := Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got.ID != 1 || !bytes.Equal(got.Inner.FullBytes, data[5:]) {
+		t.Errorf("unexpected result %+v", got)
+	}
+	if _, _, err := DecodeWithOptions(data, nil); err != nil {
+		t.Fatalf("DecodeWithOptions failed: %v", err)
+	}
+
+	opts := DefaultMarshalOptions()
+	opts.Limits = &DecodeOptions{MaxDepth: 256, Strict: true}
+	var syntax *SyntaxError
+	err := UnmarshalWithOptions(data, &got, opts)
+	if !errors.As(err, &syntax) || syntax.Offset != 9 {
+		t.Errorf("UnmarshalWithOptions error = %v, want *SyntaxError at offset 9", err)
+	}
+	_, _, err = DecodeWithOptions(data, opts.Limits)
+	if !errors.As(err, &syntax) || syntax.Offset != 9 {
+		t.Errorf("DecodeWithOptions error = %v, want *SyntaxError at offset 9", err)
+	}
+}
@@ -0,0 +1,85 @@
+package asn1
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// parseDefault converts a field's default:VALUE option to a value of type t.
+// Booleans, integers (including enumerations) and strings are supported;
+// for pointer fields the value has the pointed-to type.
+func parseDefault(info *fieldInfo, t reflect.Type) (reflect.Value, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	value := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(info.Default)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid boolean default %q", info.Default)
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(info.Default, 10, 64)
+		if err != nil || value.OverflowInt(i) {
+			return reflect.Value{}, fmt.Errorf("invalid integer default %q for %v", info.Default, t)
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(info.Default, 10, 64)
+		if err != nil || value.OverflowUint(u) {
+			return reflect.Value{}, fmt.Errorf("invalid integer default %q for %v", info.Default, t)
+		}
+		value.SetUint(u)
+	case reflect.String:
+		value.SetString(info.Default)
+	default:
+		return reflect.Value{}, fmt.Errorf("default values are not supported for %v", t)
+	}
+	return value, nil
+}
+
+// isDefaultValue reports whether a field holds its DEFAULT value, in which
+// case DER requires it to be omitted
+func isDefaultValue(v reflect.Value, info *fieldInfo) (bool, error) {
+	def, err := parseDefault(info, v.Type())
+	if err != nil {
+		return false, err
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return false, nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface() == def.Interface(), nil
+}
+
+// setAbsentField stores the value of an optional field missing from the
+// encoding: its DEFAULT value if it has one, otherwise the zero value
+func setAbsentField(v reflect.Value, info *fieldInfo) error {
+	if info.HasDefault {
+		return setDefaultValue(v, info)
+	}
+	v.Set(reflect.Zero(v.Type()))
+	return nil
+}
+
+// setDefaultValue stores a field's DEFAULT value
+func setDefaultValue(v reflect.Value, info *fieldInfo) error {
+	def, err := parseDefault(info, v.Type())
+	if err != nil {
+		return err
+	}
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(v.Type().Elem())
+		ptr.Elem().Set(def)
+		v.Set(ptr)
+		return nil
+	}
+	v.Set(def)
+	return nil
+}
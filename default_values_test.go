@@ -0,0 +1,73 @@
+package asn1
+
+import (
+	"bytes"
+	"testing"
+)
+
+type tbsWithVersion struct {
+	Version int64  `asn1:"integer,tag:0,explicit,default:0"`
+	Serial  int64  `asn1:"integer"`
+	Status  int    `asn1:"enumerated,default:1"`
+	Comment string `asn1:"utf8string,default:none"`
+}
+
+func TestDefaultValuesOmittedAndRestored(t *testing.T) {
+	encoded, err := Marshal(tbsWithVersion{Version: 0, Serial: 9, Status: 1, Comment: "none"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x03, 0x02, 0x01, 0x09}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+
+	decoded := tbsWithVersion{Version: 7, Status: 5}
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded != (tbsWithVersion{Version: 0, Serial: 9, Status: 1, Comment: "none"}) {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+}
+
+func TestNonDefaultValuesEncoded(t *testing.T) {
+	original := tbsWithVersion{Version: 2, Serial: 9, Status: 3, Comment: "hi"}
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x0F, 0xA0, 0x03, 0x02, 0x01, 0x02, 0x02, 0x01, 0x09, 0x0A, 0x01, 0x03, 0x0C, 0x02, 'h', 'i'}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+
+	var decoded tbsWithVersion
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded != original {
+		t.Errorf("Unmarshal() = %+v, want %+v", decoded, original)
+	}
+}
+
+func TestInvalidDefault(t *testing.T) {
+	type bad struct {
+		Flag bool `asn1:"boolean,default:maybe"`
+	}
+	if _, err := Marshal(bad{}); err == nil {
+		t.Errorf("expected error for invalid boolean default")
+	}
+
+	type pointer struct {
+		ID    int64  `asn1:"integer"`
+		Level *int64 `asn1:"integer,default:3"`
+	}
+	var decoded pointer
+	if err := Unmarshal([]byte{0x30, 0x03, 0x02, 0x01, 0x01}, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Level == nil || *decoded.Level != 3 {
+		t.Errorf("Level = %v, want 3", decoded.Level)
+	}
+}
@@ -0,0 +1,278 @@
+package asn1
+
+import (
+	"bytes"
+	stdasn1 "encoding/asn1"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The differential tests decode the same input with encoding/asn1 and with
+// this package. encoding/asn1 only accepts DER, which this package must
+// decode to the same value and re-encode byte for byte; BER this package
+// accepts beyond DER is not compared.
+
+// diffRecordStd and diffRecord describe the same SEQUENCE for each package
+type diffRecordStd struct {
+	Version int64
+	Serial  *big.Int
+	Enabled bool
+	ID      stdasn1.ObjectIdentifier
+	Name    string `asn1:"utf8"`
+	Data    []byte
+	Issued  time.Time `asn1:"generalized"`
+}
+
+type diffRecord struct {
+	Version int64     `asn1:"integer"`
+	Serial  int64     `asn1:"integer"`
+	Enabled bool      `asn1:"boolean"`
+	ID      []int     `asn1:"objectidentifier"`
+	Name    string    `asn1:"utf8string"`
+	Data    []byte    `asn1:"octetstring"`
+	Issued  time.Time `asn1:"generalizedtime"`
+}
+
+// checkDifferential compares the decoding of a single DER element
+func checkDifferential(t *testing.T, data []byte) {
+	t.Helper()
+
+	var raw stdasn1.RawValue
+	rest, err := stdasn1.Unmarshal(data, &raw)
+	if err != nil || len(rest) != 0 {
+		return
+	}
+	if raw.Class != stdasn1.ClassUniversal || raw.IsCompound {
+		return
+	}
+
+	var obj ASN1Object
+	var consumed int
+	switch raw.Tag {
+	case stdasn1.TagBoolean:
+		var want bool
+		if _, err := stdasn1.Unmarshal(data, &want); err != nil {
+			return
+		}
+		got, n, err := DecodeBoolean(data)
+		if err != nil {
+			t.Fatalf("DecodeBoolean(%x) failed: %v", data, err)
+		}
+		if got.Value() != want {
+			t.Fatalf("DecodeBoolean(%x) = %v, encoding/asn1 gives %v", data, got.Value(), want)
+		}
+		obj, consumed = got, n
+
+	case stdasn1.TagInteger:
+		want := new(big.Int)
+		if _, err := stdasn1.Unmarshal(data, &want); err != nil {
+			return
+		}
+		got, n, err := DecodeInteger(data)
+		if err != nil {
+			t.Fatalf("DecodeInteger(%x) failed: %v", data, err)
+		}
+		if got.Value().Cmp(want) != 0 {
+			t.Fatalf("DecodeInteger(%x) = %v, encoding/asn1 gives %v", data, got.Value(), want)
+		}
+		obj, consumed = got, n
+
+	case stdasn1.TagBitString:
+		var want stdasn1.BitString
+		if _, err := stdasn1.Unmarshal(data, &want); err != nil {
+			return
+		}
+		got, n, err := DecodeBitString(data)
+		if err != nil {
+			t.Fatalf("DecodeBitString(%x) failed: %v", data, err)
+		}
+		if !bytes.Equal(got.Value(), want.Bytes) || got.BitLength() != want.BitLength {
+			t.Fatalf("DecodeBitString(%x) = %x/%d bits, encoding/asn1 gives %x/%d bits",
+				data, got.Value(), got.BitLength(), want.Bytes, want.BitLength)
+		}
+		obj, consumed = got, n
+
+	case stdasn1.TagOctetString:
+		var want []byte
+		if _, err := stdasn1.Unmarshal(data, &want); err != nil {
+			return
+		}
+		got, n, err := DecodeOctetString(data)
+		if err != nil {
+			t.Fatalf("DecodeOctetString(%x) failed: %v", data, err)
+		}
+		if !bytes.Equal(got.Value(), want) {
+			t.Fatalf("DecodeOctetString(%x) = %x, encoding/asn1 gives %x", data, got.Value(), want)
+		}
+		obj, consumed = got, n
+
+	case stdasn1.TagOID:
+		var want stdasn1.ObjectIdentifier
+		if _, err := stdasn1.Unmarshal(data, &want); err != nil {
+			return
+		}
+		got, n, err := DecodeObjectIdentifier(data)
+		if err != nil {
+			t.Fatalf("DecodeObjectIdentifier(%x) failed: %v", data, err)
+		}
+		if !want.Equal(got.Components()) {
+			t.Fatalf("DecodeObjectIdentifier(%x) = %v, encoding/asn1 gives %v", data, got.Components(), want)
+		}
+		obj, consumed = got, n
+
+	case stdasn1.TagEnum:
+		var want stdasn1.Enumerated
+		if _, err := stdasn1.Unmarshal(data, &want); err != nil {
+			return
+		}
+		got, n, err := DecodeEnumerated(data)
+		if err != nil {
+			t.Fatalf("DecodeEnumerated(%x) failed: %v", data, err)
+		}
+		if got.Value().Cmp(big.NewInt(int64(want))) != 0 {
+			t.Fatalf("DecodeEnumerated(%x) = %v, encoding/asn1 gives %v", data, got.Value(), want)
+		}
+		obj, consumed = got, n
+
+	case stdasn1.TagUTF8String, stdasn1.TagPrintableString, stdasn1.TagIA5String, stdasn1.TagNumericString:
+		var want string
+		if _, err := stdasn1.Unmarshal(data, &want); err != nil {
+			return
+		}
+		if raw.Tag == stdasn1.TagPrintableString && strings.ContainsAny(want, "*&") {
+			// encoding/asn1 accepts these, found in broken certificates
+			return
+		}
+		got, n, err := DecodeWithOptions(data, nil)
+		if err != nil {
+			t.Fatalf("DecodeWithOptions(%x) failed: %v", data, err)
+		}
+		value, ok := stringObjectValue(got)
+		if !ok {
+			t.Fatalf("DecodeWithOptions(%x) gave %T, encoding/asn1 gives string %q", data, got, want)
+		}
+		if value != want {
+			t.Fatalf("DecodeWithOptions(%x) = %q, encoding/asn1 gives %q", data, value, want)
+		}
+		obj, consumed = got, n
+
+	case stdasn1.TagUTCTime, stdasn1.TagGeneralizedTime:
+		var want time.Time
+		if _, err := stdasn1.Unmarshal(data, &want); err != nil {
+			return
+		}
+		var got time.Time
+		if raw.Tag == stdasn1.TagUTCTime {
+			utc, n, err := DecodeUTCTime(data)
+			if err != nil {
+				t.Fatalf("DecodeUTCTime(%x) failed: %v", data, err)
+			}
+			got, obj, consumed = utc.Time(), utc, n
+		} else {
+			generalized, n, err := DecodeGeneralizedTime(data)
+			if err != nil {
+				t.Fatalf("DecodeGeneralizedTime(%x) failed: %v", data, err)
+			}
+			got, obj, consumed = generalized.Time(), generalized, n
+		}
+		if !got.Equal(want) {
+			t.Fatalf("decoding %x gives %v, encoding/asn1 gives %v", data, got, want)
+		}
+
+	default:
+		return
+	}
+
+	if consumed != len(data) {
+		t.Fatalf("decoding %x consumed %d bytes", data, consumed)
+	}
+	encoded, err := obj.Encode()
+	if err != nil {
+		t.Fatalf("Encode of %x failed: %v", data, err)
+	}
+	if !bytes.Equal(encoded, data) {
+		t.Fatalf("DER %x re-encodes as %x", data, encoded)
+	}
+}
+
+// checkDifferentialRecord compares the decoding of a DER SEQUENCE into structs
+func checkDifferentialRecord(t *testing.T, data []byte) {
+	t.Helper()
+
+	var want diffRecordStd
+	if rest, err := stdasn1.Unmarshal(data, &want); err != nil || len(rest) != 0 {
+		return
+	}
+	if !want.Serial.IsInt64() {
+		return
+	}
+
+	var got diffRecord
+	if err := Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal(%x) failed: %v", data, err)
+	}
+	if got.Version != want.Version || got.Serial != want.Serial.Int64() || got.Enabled != want.Enabled ||
+		!want.ID.Equal(got.ID) || got.Name != want.Name || !bytes.Equal(got.Data, want.Data) ||
+		!got.Issued.Equal(want.Issued) {
+		t.Fatalf("Unmarshal(%x) = %+v, encoding/asn1 gives %+v", data, got, want)
+	}
+}
+
+func differentialSeeds() [][]byte {
+	values := []interface{}{
+		true, false,
+		0, -1, 127, 128, -128, -129, -256, -32768, int64(1) << 62, int64(-1) << 63,
+		new(big.Int).Lsh(big.NewInt(1), 100), new(big.Int).Lsh(big.NewInt(-1), 100),
+		stdasn1.BitString{Bytes: []byte{0x80}, BitLength: 1},
+		stdasn1.BitString{},
+		[]byte{}, []byte{0x01, 0x02},
+		stdasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11},
+		stdasn1.ObjectIdentifier{2, 999, 3},
+		stdasn1.Enumerated(3), stdasn1.Enumerated(-128),
+		"hello",
+		time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		time.Date(2051, 1, 2, 3, 4, 5, 0, time.UTC),
+		diffRecordStd{
+			Version: 2, Serial: big.NewInt(12345), Enabled: true,
+			ID: stdasn1.ObjectIdentifier{2, 5, 4, 3}, Name: "Example", Data: []byte{0xDE, 0xAD},
+			Issued: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+
+	var seeds [][]byte
+	for _, value := range values {
+		data, err := stdasn1.Marshal(value)
+		if err != nil {
+			panic(err)
+		}
+		seeds = append(seeds, data)
+	}
+	for _, params := range []string{"utf8", "ia5", "numeric"} {
+		value := "12345"
+		data, err := stdasn1.MarshalWithParams(value, params)
+		if err != nil {
+			panic(err)
+		}
+		seeds = append(seeds, data)
+	}
+	return seeds
+}
+
+func TestDifferentialSeeds(t *testing.T) {
+	for _, data := range differentialSeeds() {
+		checkDifferential(t, data)
+		checkDifferentialRecord(t, data)
+	}
+}
+
+func FuzzDifferential(f *testing.F) {
+	for _, data := range differentialSeeds() {
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		checkDifferential(t, data)
+		checkDifferentialRecord(t, data)
+	})
+}
@@ -123,7 +123,8 @@ func encodeIntegerValue(value *big.Int) []byte {
 	if value.Sign() < 0 {
 		// Calculate two's complement
 		// First, determine the minimum number of bytes needed
-		bitLen := value.BitLen() + 1 // +1 for sign bit
+		// -2^(n-1) still fits in n bits, so size by the magnitude less one
+		bitLen := new(big.Int).Not(value).BitLen() + 1 // +1 for sign bit
 		byteLen := (bitLen + 7) / 8
 		
 		// Create a mask for the required number of bytes
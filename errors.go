@@ -0,0 +1,166 @@
+package asn1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SyntaxError reports input that is not valid BER, such as a truncated
+// element, a malformed length or bytes after the value
+type SyntaxError struct {
+	Offset int    // byte offset in the input, or -1 if unknown
+	Path   string // Go field path being decoded, such as Cert.Extensions[3]
+	Msg    string
+	Err    error // underlying error, if any
+}
+
+// Error returns the error message with its location
+func (e *SyntaxError) Error() string {
+	return formatDecodeError("syntax error", e.Offset, e.Path, e.Msg, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// StructuralError reports valid BER that does not fit the Go value it is
+// decoded into, such as a missing required field or an integer out of range
+type StructuralError struct {
+	Offset int    // byte offset of the element in the input, or -1 if unknown
+	Path   string // Go field path being decoded, such as Cert.Extensions[3].Critical
+	Msg    string
+	Err    error // underlying error, if any
+}
+
+// Error returns the error message with its location
+func (e *StructuralError) Error() string {
+	return formatDecodeError("structural error", e.Offset, e.Path, e.Msg, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *StructuralError) Unwrap() error {
+	return e.Err
+}
+
+// TagMismatchError reports an element whose tag is not the one the Go value
+// being decoded into requires
+type TagMismatchError struct {
+	Offset   int    // byte offset of the element in the input, or -1 if unknown
+	Path     string // Go field path being decoded
+	Expected Tag
+	Actual   Tag
+}
+
+// Error returns the error message with its location
+func (e *TagMismatchError) Error() string {
+	msg := fmt.Sprintf("expected tag %s, got %s", e.Expected.TagString(), e.Actual.TagString())
+	return formatDecodeError("tag mismatch", e.Offset, e.Path, msg, nil)
+}
+
+// LimitError reports input exceeding one of the DecodeOptions limits
+type LimitError struct {
+	Offset int    // byte offset of the element in the input
+	Limit  string // name of the DecodeOptions field, such as "MaxDepth"
+	Max    int    // value of the limit
+}
+
+// Error returns the error message with its location
+func (e *LimitError) Error() string {
+	return formatDecodeError("limit exceeded", e.Offset, "", fmt.Sprintf("%s of %d", e.Limit, e.Max), nil)
+}
+
+// formatDecodeError formats the message shared by the decoding errors
+func formatDecodeError(kind string, offset int, path, msg string, err error) string {
+	var b strings.Builder
+	b.WriteString("asn1: ")
+	b.WriteString(kind)
+	if offset >= 0 {
+		fmt.Fprintf(&b, " at offset %d", offset)
+	}
+	if path != "" {
+		fmt.Fprintf(&b, " in %s", path)
+	}
+	b.WriteString(": ")
+	b.WriteString(msg)
+	if err != nil {
+		if msg != "" {
+			b.WriteString(": ")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// newTagMismatch reports that obj does not have the expected tag
+func newTagMismatch(expected Tag, obj ASN1Object) *TagMismatchError {
+	return &TagMismatchError{Offset: -1, Expected: expected, Actual: obj.Tag()}
+}
+
+// newStructuralError reports a decoding error whose location is filled in by atPath
+func newStructuralError(format string, args ...interface{}) *StructuralError {
+	return &StructuralError{Offset: -1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// atPath locates a decoding error at element, the object being decoded
+// into the Go value named by segment (a field name or an [index]). Plain
+// errors become StructuralErrors; typed ones, even when wrapped, are updated
+// in place and keep the innermost offset.
+func atPath(err error, segment string, element ASN1Object, opts *MarshalOptions) error {
+	offset := -1
+	if opts.state != nil {
+		if at, ok := opts.state.offsets[element]; ok {
+			offset = at
+		}
+	}
+
+	// The outermost typed error in the chain holds the path
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch typed := e.(type) {
+		case *SyntaxError:
+			typed.Path = joinPath(segment, typed.Path)
+			if typed.Offset < 0 {
+				typed.Offset = offset
+			}
+			return err
+		case *StructuralError:
+			typed.Path = joinPath(segment, typed.Path)
+			if typed.Offset < 0 {
+				typed.Offset = offset
+			}
+			return err
+		case *TagMismatchError:
+			typed.Path = joinPath(segment, typed.Path)
+			if typed.Offset < 0 {
+				typed.Offset = offset
+			}
+			return err
+		}
+	}
+	return &StructuralError{Offset: offset, Path: segment, Err: err}
+}
+
+// joinPath prefixes a field path with a parent segment
+func joinPath(segment, path string) string {
+	switch {
+	case segment == "":
+		return path
+	case path == "":
+		return segment
+	case strings.HasPrefix(path, "["):
+		return segment + path
+	default:
+		return segment + "." + path
+	}
+}
+
+// typeMismatch reports that obj cannot be decoded into a Go value whose
+// ASN.1 type has the expected tag: a TagMismatchError if its tag differs,
+// otherwise a StructuralError with msg, as the content is malformed
+func typeMismatch(expected Tag, obj ASN1Object, msg string) error {
+	if actual := obj.Tag(); actual.Class != expected.Class || actual.Number != expected.Number {
+		return newTagMismatch(expected, obj)
+	}
+	return newStructuralError("%s", msg)
+}
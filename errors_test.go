@@ -0,0 +1,81 @@
+package asn1
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type errorExtension struct {
+	ID       string `asn1:"objectidentifier"`
+	Critical bool   `asn1:"boolean"`
+}
+
+type errorCert struct {
+	Serial     int64            `asn1:"integer"`
+	Extensions []errorExtension `asn1:"sequence"`
+}
+
+type errorEnvelope struct {
+	Cert errorCert `asn1:"sequence"`
+}
+
+func TestTagMismatchErrorLocation(t *testing.T) {
+	data := []byte{
+		0x30, 0x17, // envelope
+		0x30, 0x15, // cert
+		0x02, 0x01, 0x01, // serial
+		0x30, 0x10, // extensions
+		0x30, 0x06, 0x06, 0x01, 0x2A, 0x01, 0x01, 0xFF,
+		0x30, 0x06, 0x06, 0x01, 0x2A, 0x02, 0x01, 0x00, // Critical is an INTEGER at offset 22
+	}
+
+	var envelope errorEnvelope
+	err := Unmarshal(data, &envelope)
+
+	var mismatch *TagMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Unmarshal() error = %v, want *TagMismatchError", err)
+	}
+	if mismatch.Path != "Cert.Extensions[1].Critical" || mismatch.Offset != 22 {
+		t.Errorf("TagMismatchError = %+v", mismatch)
+	}
+	if mismatch.Expected.Number != TagBoolean || mismatch.Actual.Number != TagInteger {
+		t.Errorf("TagMismatchError tags = %v, %v", mismatch.Expected, mismatch.Actual)
+	}
+	if !strings.Contains(err.Error(), "at offset 22 in Cert.Extensions[1].Critical") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestStructuralAndSyntaxErrors(t *testing.T) {
+	// A missing required field is reported at the enclosing SEQUENCE
+	var cert errorCert
+	err := Unmarshal([]byte{0x30, 0x03, 0x02, 0x01, 0x01}, &cert)
+	var structural *StructuralError
+	if !errors.As(err, &structural) || structural.Path != "Extensions" || structural.Offset != 0 {
+		t.Errorf("Unmarshal() error = %#v", err)
+	}
+
+	// Truncated input is a syntax error
+	err = Unmarshal([]byte{0x30, 0x05, 0x02, 0x01}, &cert)
+	var syntax *SyntaxError
+	if !errors.As(err, &syntax) || syntax.Offset != 0 {
+		t.Errorf("Unmarshal() error = %v, want *SyntaxError", err)
+	}
+
+	// A malformed element inside the outer SEQUENCE is located
+	err = Unmarshal([]byte{0x30, 0x05, 0x02, 0x01, 0x01, 0x30, 0x05}, &cert)
+	if !errors.As(err, &syntax) || syntax.Offset != 5 {
+		t.Errorf("Unmarshal() error = %v, want *SyntaxError at offset 5", err)
+	}
+
+	// An out of range value keeps its cause
+	var small struct {
+		N uint8 `asn1:"integer"`
+	}
+	err = Unmarshal([]byte{0x30, 0x03, 0x02, 0x01, 0xFF}, &small)
+	if !errors.As(err, &structural) || structural.Path != "N" || structural.Offset != 2 {
+		t.Errorf("Unmarshal() error = %v", err)
+	}
+}
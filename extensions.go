@@ -0,0 +1,72 @@
+package asn1
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var rawValuesType = reflect.TypeOf([]RawValue(nil))
+
+// isExtensionsField reports whether a field stands for the extension marker
+// (...) of an extensible SEQUENCE or CHOICE, tagged asn1:"extensions" or
+// asn1:"...". In a SEQUENCE it is a []RawValue holding the components after
+// the last known one; in a CHOICE it is a *RawValue holding an alternative
+// whose tag is not known.
+func isExtensionsField(info *fieldInfo) bool {
+	return info.Type == "extensions"
+}
+
+// marshalExtensions returns the unknown extensions held by a SEQUENCE's
+// extensions field, to be re-emitted unchanged after its other components
+func marshalExtensions(v reflect.Value) ([]ASN1Object, error) {
+	if v.Type() != rawValuesType {
+		return nil, fmt.Errorf("extensions field must be []RawValue, got %v", v.Type())
+	}
+	objs := make([]ASN1Object, v.Len())
+	for i := range objs {
+		raw := v.Index(i).Interface().(RawValue)
+		objs[i] = &rawObject{raw: &raw}
+	}
+	return objs, nil
+}
+
+// setExtensions stores the components left after the last known one in a
+// SEQUENCE's extensions field
+func setExtensions(v reflect.Value, elements []ASN1Object, opts *MarshalOptions) error {
+	if v.Type() != rawValuesType {
+		return fmt.Errorf("extensions field must be []RawValue, got %v", v.Type())
+	}
+	if len(elements) == 0 {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	extensions := make([]RawValue, len(elements))
+	for i, element := range elements {
+		encoded, err := unmarshaledEncoding(element, opts)
+		if err != nil {
+			return fmt.Errorf("failed to capture extension %d: %w", i, err)
+		}
+		raw, err := ParseRawValue(encoded)
+		if err != nil {
+			return fmt.Errorf("failed to capture extension %d: %w", i, err)
+		}
+		extensions[i] = raw
+	}
+	v.Set(reflect.ValueOf(extensions))
+	return nil
+}
+
+// setUnknownAlternative stores an alternative with an unknown tag in a
+// CHOICE's extensions field
+func setUnknownAlternative(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error {
+	if v.Type() != reflect.PointerTo(rawValueType) {
+		return fmt.Errorf("extensions alternative must be *RawValue, got %v", v.Type())
+	}
+	raw := reflect.New(rawValueType)
+	if err := unmarshalRaw(obj, raw.Elem(), opts); err != nil {
+		return err
+	}
+	v.Set(raw)
+	return nil
+}
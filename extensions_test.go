@@ -0,0 +1,117 @@
+package asn1
+
+import (
+	"bytes"
+	"testing"
+)
+
+type callInfoV1 struct {
+	CallID     int64      `asn1:"integer"`
+	Extensions []RawValue `asn1:"..."`
+}
+
+type callInfoV2 struct {
+	CallID   int64   `asn1:"integer"`
+	Duration *int64  `asn1:"integer,optional,tag:0"`
+	Caller   *string `asn1:"utf8string,optional,tag:1"`
+}
+
+func TestSequenceExtensionsPreserved(t *testing.T) {
+	duration := int64(30)
+	caller := "a"
+	newer, err := Marshal(callInfoV2{CallID: 1, Duration: &duration, Caller: &caller})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var older callInfoV1
+	if err := Unmarshal(newer, &older); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if older.CallID != 1 || len(older.Extensions) != 2 {
+		t.Fatalf("Unmarshal() = %+v", older)
+	}
+	if ext := older.Extensions[0]; ext.Class != 2 || ext.Tag != 0 || !bytes.Equal(ext.Bytes, []byte{30}) {
+		t.Errorf("Extensions[0] = %+v", ext)
+	}
+
+	// Re-encoding keeps the unknown components unchanged
+	reencoded, err := Marshal(older)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !bytes.Equal(reencoded, newer) {
+		t.Errorf("Marshal() = %x, want %x", reencoded, newer)
+	}
+
+	// Without extensions the field is cleared
+	older.Extensions = []RawValue{{}}
+	if err := Unmarshal([]byte{0x30, 0x03, 0x02, 0x01, 0x01}, &older); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if older.Extensions != nil {
+		t.Errorf("Extensions = %+v, want nil", older.Extensions)
+	}
+}
+
+func TestSequenceExtensionsKeepBERBytes(t *testing.T) {
+	// A BOOLEAN true written as 0x01 and a UTCTime without seconds, neither DER
+	data := []byte{0x30, 0x13, 0x02, 0x01, 0x01, 0x01, 0x01, 0x01,
+		0x17, 0x0B, '2', '4', '0', '1', '0', '1', '1', '2', '0', '0', 'Z'}
+
+	var older callInfoV1
+	if err := Unmarshal(data, &older); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(older.Extensions) != 2 || !bytes.Equal(older.Extensions[0].FullBytes, data[5:8]) ||
+		!bytes.Equal(older.Extensions[1].FullBytes, data[8:]) {
+		t.Fatalf("Unmarshal() = %+v", older)
+	}
+
+	reencoded, err := Marshal(older)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !bytes.Equal(reencoded, data) {
+		t.Errorf("Marshal() = %x, want %x", reencoded, data)
+	}
+}
+
+type extensibleChoice struct {
+	Number  *int64    `asn1:"integer,tag:0"`
+	Text    *string   `asn1:"utf8string,tag:1"`
+	Unknown *RawValue `asn1:"extensions"`
+}
+
+func TestChoiceUnknownAlternative(t *testing.T) {
+	type holder struct {
+		Value extensibleChoice `asn1:"choice"`
+	}
+
+	// [5] is an alternative added by a newer version
+	data := []byte{0x30, 0x04, 0x85, 0x02, 0xAB, 0xCD}
+	decoded := holder{Value: extensibleChoice{Number: new(int64)}}
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	unknown := decoded.Value.Unknown
+	if decoded.Value.Number != nil || unknown == nil || unknown.Tag != 5 || !bytes.Equal(unknown.Bytes, []byte{0xAB, 0xCD}) {
+		t.Fatalf("Unmarshal() = %+v", decoded.Value)
+	}
+
+	encoded, err := Marshal(decoded)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !bytes.Equal(encoded, data) {
+		t.Errorf("Marshal() = %x, want %x", encoded, data)
+	}
+
+	// Known alternatives still decode normally
+	if err := Unmarshal([]byte{0x30, 0x03, 0x80, 0x01, 0x07}, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Value.Number == nil || *decoded.Value.Number != 7 || decoded.Value.Unknown != nil {
+		t.Errorf("Unmarshal() = %+v", decoded.Value)
+	}
+}
@@ -0,0 +1,848 @@
+package asn1
+
+import (
+	"fmt"
+)
+
+// ExternalEncoding identifies which alternative of the EXTERNAL encoding CHOICE is used
+type ExternalEncoding int
+
+const (
+	// ExternalSingleASN1Type carries a single ASN.1 value: [0] EXPLICIT
+	ExternalSingleASN1Type ExternalEncoding = iota
+	// ExternalOctetAligned carries an octet-aligned value: [1] IMPLICIT OCTET STRING
+	ExternalOctetAligned
+	// ExternalArbitrary carries an arbitrary bit string: [2] IMPLICIT BIT STRING
+	ExternalArbitrary
+)
+
+// String returns the ASN.1 identifier of the encoding alternative
+func (e ExternalEncoding) String() string {
+	switch e {
+	case ExternalSingleASN1Type:
+		return "single-ASN1-type"
+	case ExternalOctetAligned:
+		return "octet-aligned"
+	case ExternalArbitrary:
+		return "arbitrary"
+	default:
+		return fmt.Sprintf("ExternalEncoding(%d)", int(e))
+	}
+}
+
+// ASN1External represents an ASN.1 EXTERNAL value, encoded with the
+// X.690 section 8.18 structure:
+//
+//	[UNIVERSAL 8] IMPLICIT SEQUENCE {
+//	    direct-reference      OBJECT IDENTIFIER OPTIONAL,
+//	    indirect-reference    INTEGER OPTIONAL,
+//	    data-value-descriptor ObjectDescriptor OPTIONAL,
+//	    encoding CHOICE {
+//	        single-ASN1-type [0] ABSTRACT-SYNTAX.&Type,
+//	        octet-aligned    [1] IMPLICIT OCTET STRING,
+//	        arbitrary        [2] IMPLICIT BIT STRING } }
+type ASN1External struct {
+	directReference     *ASN1ObjectIdentifier
+	indirectReference   *int64
+	dataValueDescriptor *string
+	encoding            ExternalEncoding
+	value               ASN1Object
+}
+
+// NewExternal creates an EXTERNAL carrying a single ASN.1 value.
+// directReference may be nil when the syntax is identified by an indirect reference.
+func NewExternal(directReference *ASN1ObjectIdentifier, value ASN1Object) *ASN1External {
+	return &ASN1External{
+		directReference: directReference,
+		encoding:        ExternalSingleASN1Type,
+		value:           value,
+	}
+}
+
+// NewInstanceOf creates the encoding of INSTANCE OF TYPE-IDENTIFIER.
+// X.681 Annex C defines it as SEQUENCE { type-id [UNIVERSAL 8] IMPLICIT OID,
+// value [0] EXPLICIT ANY }, which is exactly an EXTERNAL with only a direct
+// reference and a single-ASN1-type encoding; DecodeExternal reads it back.
+func NewInstanceOf(typeID *ASN1ObjectIdentifier, value ASN1Object) *ASN1External {
+	return NewExternal(typeID, value)
+}
+
+// NewExternalOctetAligned creates an EXTERNAL carrying an octet-aligned value
+func NewExternalOctetAligned(directReference *ASN1ObjectIdentifier, data []byte) *ASN1External {
+	return &ASN1External{
+		directReference: directReference,
+		encoding:        ExternalOctetAligned,
+		value:           NewOctetString(data),
+	}
+}
+
+// NewExternalArbitrary creates an EXTERNAL carrying an arbitrary bit string
+func NewExternalArbitrary(directReference *ASN1ObjectIdentifier, bits *ASN1BitString) *ASN1External {
+	return &ASN1External{
+		directReference: directReference,
+		encoding:        ExternalArbitrary,
+		value:           bits,
+	}
+}
+
+// DirectReference returns the direct reference, or nil if absent
+func (e *ASN1External) DirectReference() *ASN1ObjectIdentifier {
+	return e.directReference
+}
+
+// IndirectReference returns the indirect reference (a presentation context identifier)
+func (e *ASN1External) IndirectReference() (int64, bool) {
+	if e.indirectReference == nil {
+		return 0, false
+	}
+	return *e.indirectReference, true
+}
+
+// SetIndirectReference sets the indirect reference
+func (e *ASN1External) SetIndirectReference(ref int64) {
+	e.indirectReference = &ref
+}
+
+// DataValueDescriptor returns the data value descriptor
+func (e *ASN1External) DataValueDescriptor() (string, bool) {
+	if e.dataValueDescriptor == nil {
+		return "", false
+	}
+	return *e.dataValueDescriptor, true
+}
+
+// SetDataValueDescriptor sets the data value descriptor
+func (e *ASN1External) SetDataValueDescriptor(descriptor string) {
+	e.dataValueDescriptor = &descriptor
+}
+
+// Encoding returns which encoding alternative holds the value
+func (e *ASN1External) Encoding() ExternalEncoding {
+	return e.encoding
+}
+
+// Value returns the carried value: the ASN.1 value itself for
+// single-ASN1-type, an *ASN1OctetString for octet-aligned and an
+// *ASN1BitString for arbitrary
+func (e *ASN1External) Value() ASN1Object {
+	return e.value
+}
+
+// Tag returns the ASN.1 tag for EXTERNAL
+func (e *ASN1External) Tag() Tag {
+	return NewUniversalTag(TagExternal, true)
+}
+
+// Encode returns the BER encoding of the EXTERNAL value
+func (e *ASN1External) Encode() ([]byte, error) {
+	if e.value == nil {
+		return nil, fmt.Errorf("EXTERNAL has no value")
+	}
+	if e.directReference == nil && e.indirectReference == nil {
+		return nil, fmt.Errorf("EXTERNAL needs a direct or indirect reference")
+	}
+
+	var content []byte
+	if e.directReference != nil {
+		encoded, err := e.directReference.Encode()
+		if err != nil {
+			return nil, fmt.Errorf("direct-reference: %w", err)
+		}
+		content = append(content, encoded...)
+	}
+	if e.indirectReference != nil {
+		encoded, err := NewInteger(*e.indirectReference).Encode()
+		if err != nil {
+			return nil, fmt.Errorf("indirect-reference: %w", err)
+		}
+		content = append(content, encoded...)
+	}
+	if e.dataValueDescriptor != nil {
+		if !isGraphicString(*e.dataValueDescriptor) {
+			return nil, fmt.Errorf("data-value-descriptor contains invalid characters")
+		}
+		encoded, err := EncodeTLV(NewUniversalTag(TagObjectDescriptor, false), encodeLatin1(*e.dataValueDescriptor))
+		if err != nil {
+			return nil, fmt.Errorf("data-value-descriptor: %w", err)
+		}
+		content = append(content, encoded...)
+	}
+
+	var encoded []byte
+	var err error
+	switch e.encoding {
+	case ExternalSingleASN1Type:
+		var inner []byte
+		inner, err = e.value.Encode()
+		if err == nil {
+			encoded, err = EncodeTLV(NewContextSpecificTag(0, true), inner)
+		}
+	case ExternalOctetAligned, ExternalArbitrary:
+		var value []byte
+		value, err = contentOctets(e.value)
+		if err == nil {
+			encoded, err = EncodeTLV(NewContextSpecificTag(int(e.encoding), false), value)
+		}
+	default:
+		err = fmt.Errorf("unknown encoding %d", e.encoding)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encoding: %w", err)
+	}
+	content = append(content, encoded...)
+
+	return EncodeTLV(e.Tag(), content)
+}
+
+// String returns a string representation of the EXTERNAL value
+func (e *ASN1External) String() string {
+	return fmt.Sprintf("EXTERNAL{%s%s: %s}", e.referenceString(), e.encoding, e.valueString())
+}
+
+// TaggedString returns a string representation with tag information
+func (e *ASN1External) TaggedString() string {
+	return fmt.Sprintf("%s EXTERNAL: %s%s: %s", e.Tag().TagString(), e.referenceString(), e.encoding, e.valueString())
+}
+
+func (e *ASN1External) referenceString() string {
+	var result string
+	if e.directReference != nil {
+		result += e.directReference.DotNotation() + ", "
+	}
+	if e.indirectReference != nil {
+		result += fmt.Sprintf("context %d, ", *e.indirectReference)
+	}
+	return result
+}
+
+func (e *ASN1External) valueString() string {
+	if e.value == nil {
+		return "empty"
+	}
+	return e.value.String()
+}
+
+// DecodeExternalValue decodes the contents of an EXTERNAL value
+func DecodeExternalValue(data []byte) (*ASN1External, error) {
+	elements, err := decodeContentElements(data)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &ASN1External{}
+	i := 0
+	if i < len(elements) && elements[i].tag == NewUniversalTag(TagOID, false) {
+		arcs, err := DecodeObjectIdentifierArcs(elements[i].value)
+		if err != nil {
+			return nil, fmt.Errorf("direct-reference: %w", err)
+		}
+		if e.directReference, err = NewObjectIdentifierFromArcs(arcs); err != nil {
+			return nil, fmt.Errorf("direct-reference: %w", err)
+		}
+		i++
+	}
+	if i < len(elements) && elements[i].tag == NewUniversalTag(TagInteger, false) {
+		ref, err := DecodeIntegerValue(elements[i].value)
+		if err != nil {
+			return nil, fmt.Errorf("indirect-reference: %w", err)
+		}
+		if !ref.IsInt64() {
+			return nil, fmt.Errorf("indirect-reference out of range")
+		}
+		e.SetIndirectReference(ref.Int64())
+		i++
+	}
+	if i < len(elements) && elements[i].tag == NewUniversalTag(TagObjectDescriptor, false) {
+		descriptor := decodeLatin1(elements[i].value)
+		if !isGraphicString(descriptor) {
+			return nil, fmt.Errorf("data-value-descriptor contains invalid characters")
+		}
+		e.SetDataValueDescriptor(descriptor)
+		i++
+	}
+	if e.directReference == nil && e.indirectReference == nil {
+		return nil, fmt.Errorf("EXTERNAL needs a direct or indirect reference")
+	}
+
+	if i != len(elements)-1 {
+		return nil, fmt.Errorf("EXTERNAL must end with exactly one encoding, got %d trailing elements", len(elements)-i)
+	}
+	encoding := elements[i]
+	if encoding.tag.Class != 2 || encoding.tag.Number > 2 {
+		return nil, fmt.Errorf("unexpected EXTERNAL encoding tag %s", encoding.tag.TagString())
+	}
+	e.encoding = ExternalEncoding(encoding.tag.Number)
+
+	switch e.encoding {
+	case ExternalSingleASN1Type:
+		if !encoding.tag.Constructed {
+			return nil, fmt.Errorf("single-ASN1-type must be constructed")
+		}
+		inner, consumed, err := DecodeTLV(encoding.value)
+		if err != nil {
+			return nil, fmt.Errorf("single-ASN1-type: %w", err)
+		}
+		if consumed != len(encoding.value) {
+			return nil, fmt.Errorf("single-ASN1-type must hold exactly one value")
+		}
+		e.value = convertToHighLevelObject(inner)
+	case ExternalOctetAligned:
+		if encoding.tag.Constructed {
+			return nil, fmt.Errorf("octet-aligned must be primitive")
+		}
+		e.value = NewOctetString(encoding.value)
+	case ExternalArbitrary:
+		if encoding.tag.Constructed {
+			return nil, fmt.Errorf("arbitrary must be primitive")
+		}
+		bits, unused, err := DecodeBitStringValue(encoding.value)
+		if err != nil {
+			return nil, fmt.Errorf("arbitrary: %w", err)
+		}
+		if e.value, err = NewBitStringChecked(bits, unused); err != nil {
+			return nil, fmt.Errorf("arbitrary: %w", err)
+		}
+	}
+
+	return e, nil
+}
+
+// DecodeExternal decodes an ASN1External from BER-encoded data
+func DecodeExternal(data []byte) (*ASN1External, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag != NewUniversalTag(TagExternal, true) {
+		return nil, 0, fmt.Errorf("expected EXTERNAL tag, got %s", asn1Value.tag.TagString())
+	}
+
+	external, err := DecodeExternalValue(asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return external, consumed, nil
+}
+
+// IdentificationKind identifies which alternative of the identification CHOICE is used
+type IdentificationKind int
+
+const (
+	// IdentificationSyntaxes names an abstract and a transfer syntax
+	IdentificationSyntaxes IdentificationKind = iota
+	// IdentificationSyntax names a single syntax
+	IdentificationSyntax
+	// IdentificationPresentationContextID refers to a negotiated presentation context
+	IdentificationPresentationContextID
+	// IdentificationContextNegotiation proposes a presentation context and transfer syntax
+	IdentificationContextNegotiation
+	// IdentificationTransferSyntax names only the transfer syntax
+	IdentificationTransferSyntax
+	// IdentificationFixed means the syntaxes are fixed by the application designer
+	IdentificationFixed
+)
+
+// String returns the ASN.1 identifier of the identification alternative
+func (k IdentificationKind) String() string {
+	switch k {
+	case IdentificationSyntaxes:
+		return "syntaxes"
+	case IdentificationSyntax:
+		return "syntax"
+	case IdentificationPresentationContextID:
+		return "presentation-context-id"
+	case IdentificationContextNegotiation:
+		return "context-negotiation"
+	case IdentificationTransferSyntax:
+		return "transfer-syntax"
+	case IdentificationFixed:
+		return "fixed"
+	default:
+		return fmt.Sprintf("IdentificationKind(%d)", int(k))
+	}
+}
+
+// Identification is the identification CHOICE shared by EMBEDDED PDV and
+// CHARACTER STRING (X.680 sections 36 and 44):
+//
+//	CHOICE {
+//	    syntaxes [0] SEQUENCE { abstract [0] OBJECT IDENTIFIER, transfer [1] OBJECT IDENTIFIER },
+//	    syntax [1] OBJECT IDENTIFIER,
+//	    presentation-context-id [2] INTEGER,
+//	    context-negotiation [3] SEQUENCE { presentation-context-id [0] INTEGER, transfer-syntax [1] OBJECT IDENTIFIER },
+//	    transfer-syntax [4] OBJECT IDENTIFIER,
+//	    fixed [5] NULL }
+type Identification struct {
+	kind      IdentificationKind
+	abstract  *ASN1ObjectIdentifier
+	transfer  *ASN1ObjectIdentifier
+	contextID int64
+}
+
+// NewSyntaxesIdentification identifies the value by its abstract and transfer syntaxes
+func NewSyntaxesIdentification(abstract, transfer *ASN1ObjectIdentifier) *Identification {
+	return &Identification{kind: IdentificationSyntaxes, abstract: abstract, transfer: transfer}
+}
+
+// NewSyntaxIdentification identifies the value by a single syntax
+func NewSyntaxIdentification(syntax *ASN1ObjectIdentifier) *Identification {
+	return &Identification{kind: IdentificationSyntax, abstract: syntax}
+}
+
+// NewPresentationContextIdentification identifies the value by a presentation context
+func NewPresentationContextIdentification(contextID int64) *Identification {
+	return &Identification{kind: IdentificationPresentationContextID, contextID: contextID}
+}
+
+// NewContextNegotiationIdentification proposes a presentation context and transfer syntax
+func NewContextNegotiationIdentification(contextID int64, transfer *ASN1ObjectIdentifier) *Identification {
+	return &Identification{kind: IdentificationContextNegotiation, contextID: contextID, transfer: transfer}
+}
+
+// NewTransferSyntaxIdentification identifies the value by its transfer syntax only
+func NewTransferSyntaxIdentification(transfer *ASN1ObjectIdentifier) *Identification {
+	return &Identification{kind: IdentificationTransferSyntax, transfer: transfer}
+}
+
+// NewFixedIdentification marks the syntaxes as fixed by the application
+func NewFixedIdentification() *Identification {
+	return &Identification{kind: IdentificationFixed}
+}
+
+// Kind returns the chosen alternative
+func (id *Identification) Kind() IdentificationKind {
+	return id.kind
+}
+
+// AbstractSyntax returns the abstract syntax for the syntaxes alternative,
+// or the syntax for the syntax alternative. It is nil otherwise.
+func (id *Identification) AbstractSyntax() *ASN1ObjectIdentifier {
+	return id.abstract
+}
+
+// TransferSyntax returns the transfer syntax for the syntaxes,
+// context-negotiation and transfer-syntax alternatives. It is nil otherwise.
+func (id *Identification) TransferSyntax() *ASN1ObjectIdentifier {
+	return id.transfer
+}
+
+// PresentationContextID returns the presentation context identifier for the
+// presentation-context-id and context-negotiation alternatives
+func (id *Identification) PresentationContextID() int64 {
+	return id.contextID
+}
+
+// String returns a string representation of the identification
+func (id *Identification) String() string {
+	switch id.kind {
+	case IdentificationSyntaxes:
+		return fmt.Sprintf("syntaxes{%s, %s}", oidString(id.abstract), oidString(id.transfer))
+	case IdentificationSyntax:
+		return fmt.Sprintf("syntax %s", oidString(id.abstract))
+	case IdentificationPresentationContextID:
+		return fmt.Sprintf("presentation-context-id %d", id.contextID)
+	case IdentificationContextNegotiation:
+		return fmt.Sprintf("context-negotiation{%d, %s}", id.contextID, oidString(id.transfer))
+	case IdentificationTransferSyntax:
+		return fmt.Sprintf("transfer-syntax %s", oidString(id.transfer))
+	default:
+		return id.kind.String()
+	}
+}
+
+// encode returns the [0] EXPLICIT identification TLV
+func (id *Identification) encode() ([]byte, error) {
+	var alternative []byte
+	var err error
+	switch id.kind {
+	case IdentificationSyntaxes:
+		alternative, err = encodeOIDPair(0, 0, id.abstract, 1, id.transfer)
+	case IdentificationSyntax:
+		alternative, err = encodeTaggedOID(1, id.abstract)
+	case IdentificationPresentationContextID:
+		alternative, err = encodeTaggedInteger(2, id.contextID)
+	case IdentificationContextNegotiation:
+		var contextID, transfer []byte
+		if contextID, err = encodeTaggedInteger(0, id.contextID); err != nil {
+			break
+		}
+		if transfer, err = encodeTaggedOID(1, id.transfer); err != nil {
+			break
+		}
+		alternative, err = EncodeTLV(NewContextSpecificTag(3, true), append(contextID, transfer...))
+	case IdentificationTransferSyntax:
+		alternative, err = encodeTaggedOID(4, id.transfer)
+	case IdentificationFixed:
+		alternative, err = EncodeTLV(NewContextSpecificTag(5, false), nil)
+	default:
+		err = fmt.Errorf("unknown identification kind %d", id.kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("identification: %w", err)
+	}
+
+	// A tagged CHOICE is always explicitly tagged
+	return EncodeTLV(NewContextSpecificTag(0, true), alternative)
+}
+
+// decodeIdentification decodes the content of the [0] identification element
+func decodeIdentification(data []byte) (*Identification, error) {
+	alternative, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, fmt.Errorf("identification: %w", err)
+	}
+	if consumed != len(data) {
+		return nil, fmt.Errorf("identification must hold exactly one alternative")
+	}
+	if alternative.tag.Class != 2 || alternative.tag.Number > int(IdentificationFixed) {
+		return nil, fmt.Errorf("unexpected identification tag %s", alternative.tag.TagString())
+	}
+
+	kind := IdentificationKind(alternative.tag.Number)
+	wantConstructed := kind == IdentificationSyntaxes || kind == IdentificationContextNegotiation
+	if alternative.tag.Constructed != wantConstructed {
+		return nil, fmt.Errorf("identification %s has the wrong encoding form", kind)
+	}
+
+	id := &Identification{kind: kind}
+	switch kind {
+	case IdentificationSyntaxes, IdentificationContextNegotiation:
+		fields, err := decodeContentElements(alternative.value)
+		if err != nil {
+			return nil, fmt.Errorf("identification %s: %w", kind, err)
+		}
+		if len(fields) != 2 || fields[0].tag != NewContextSpecificTag(0, false) || fields[1].tag != NewContextSpecificTag(1, false) {
+			return nil, fmt.Errorf("identification %s must contain [0] and [1]", kind)
+		}
+		if kind == IdentificationSyntaxes {
+			if id.abstract, err = decodeOIDContent(fields[0].value); err != nil {
+				return nil, fmt.Errorf("abstract syntax: %w", err)
+			}
+		} else if id.contextID, err = decodeInt64Content(fields[0].value); err != nil {
+			return nil, fmt.Errorf("presentation-context-id: %w", err)
+		}
+		if id.transfer, err = decodeOIDContent(fields[1].value); err != nil {
+			return nil, fmt.Errorf("transfer syntax: %w", err)
+		}
+	case IdentificationSyntax:
+		if id.abstract, err = decodeOIDContent(alternative.value); err != nil {
+			return nil, fmt.Errorf("syntax: %w", err)
+		}
+	case IdentificationPresentationContextID:
+		if id.contextID, err = decodeInt64Content(alternative.value); err != nil {
+			return nil, fmt.Errorf("presentation-context-id: %w", err)
+		}
+	case IdentificationTransferSyntax:
+		if id.transfer, err = decodeOIDContent(alternative.value); err != nil {
+			return nil, fmt.Errorf("transfer-syntax: %w", err)
+		}
+	case IdentificationFixed:
+		if len(alternative.value) != 0 {
+			return nil, fmt.Errorf("fixed must be NULL")
+		}
+	}
+	return id, nil
+}
+
+// ASN1EmbeddedPDV represents an ASN.1 EMBEDDED PDV value:
+//
+//	[UNIVERSAL 11] IMPLICIT SEQUENCE {
+//	    identification [0] Identification,
+//	    data-value     [2] OCTET STRING }
+type ASN1EmbeddedPDV struct {
+	identification *Identification
+	dataValue      []byte
+}
+
+// NewEmbeddedPDV creates a new ASN1EmbeddedPDV
+func NewEmbeddedPDV(identification *Identification, dataValue []byte) *ASN1EmbeddedPDV {
+	copied := make([]byte, len(dataValue))
+	copy(copied, dataValue)
+	return &ASN1EmbeddedPDV{identification: identification, dataValue: copied}
+}
+
+// Identification returns how the data value is identified
+func (p *ASN1EmbeddedPDV) Identification() *Identification {
+	return p.identification
+}
+
+// DataValue returns the encoded data value
+func (p *ASN1EmbeddedPDV) DataValue() []byte {
+	result := make([]byte, len(p.dataValue))
+	copy(result, p.dataValue)
+	return result
+}
+
+// Tag returns the ASN.1 tag for EMBEDDED PDV
+func (p *ASN1EmbeddedPDV) Tag() Tag {
+	return NewUniversalTag(TagEmbeddedPDV, true)
+}
+
+// Encode returns the BER encoding of the EMBEDDED PDV value
+func (p *ASN1EmbeddedPDV) Encode() ([]byte, error) {
+	content, err := encodeIdentifiedValue(p.identification, p.dataValue)
+	if err != nil {
+		return nil, fmt.Errorf("EMBEDDED PDV: %w", err)
+	}
+	return EncodeTLV(p.Tag(), content)
+}
+
+// String returns a string representation of the EMBEDDED PDV value
+func (p *ASN1EmbeddedPDV) String() string {
+	return fmt.Sprintf("EMBEDDED PDV{%s, %x}", identificationString(p.identification), p.dataValue)
+}
+
+// TaggedString returns a string representation with tag information
+func (p *ASN1EmbeddedPDV) TaggedString() string {
+	return fmt.Sprintf("%s EMBEDDED PDV: %s, %x", p.Tag().TagString(), identificationString(p.identification), p.dataValue)
+}
+
+// DecodeEmbeddedPDV decodes an ASN1EmbeddedPDV from BER-encoded data
+func DecodeEmbeddedPDV(data []byte) (*ASN1EmbeddedPDV, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag != NewUniversalTag(TagEmbeddedPDV, true) {
+		return nil, 0, fmt.Errorf("expected EMBEDDED PDV tag, got %s", asn1Value.tag.TagString())
+	}
+
+	identification, dataValue, err := decodeIdentifiedValue(asn1Value.value)
+	if err != nil {
+		return nil, 0, fmt.Errorf("EMBEDDED PDV: %w", err)
+	}
+
+	return &ASN1EmbeddedPDV{identification: identification, dataValue: dataValue}, consumed, nil
+}
+
+// ASN1CharacterString represents an ASN.1 unrestricted CHARACTER STRING value:
+//
+//	[UNIVERSAL 29] IMPLICIT SEQUENCE {
+//	    identification [0] Identification,
+//	    string-value   [2] OCTET STRING }
+type ASN1CharacterString struct {
+	identification *Identification
+	stringValue    []byte
+}
+
+// NewCharacterString creates a new ASN1CharacterString
+func NewCharacterString(identification *Identification, stringValue []byte) *ASN1CharacterString {
+	copied := make([]byte, len(stringValue))
+	copy(copied, stringValue)
+	return &ASN1CharacterString{identification: identification, stringValue: copied}
+}
+
+// Identification returns how the character abstract and transfer syntaxes are identified
+func (c *ASN1CharacterString) Identification() *Identification {
+	return c.identification
+}
+
+// StringValue returns the encoded string value
+func (c *ASN1CharacterString) StringValue() []byte {
+	result := make([]byte, len(c.stringValue))
+	copy(result, c.stringValue)
+	return result
+}
+
+// Tag returns the ASN.1 tag for CHARACTER STRING
+func (c *ASN1CharacterString) Tag() Tag {
+	return NewUniversalTag(TagCharacterString, true)
+}
+
+// Encode returns the BER encoding of the CHARACTER STRING value
+func (c *ASN1CharacterString) Encode() ([]byte, error) {
+	content, err := encodeIdentifiedValue(c.identification, c.stringValue)
+	if err != nil {
+		return nil, fmt.Errorf("CHARACTER STRING: %w", err)
+	}
+	return EncodeTLV(c.Tag(), content)
+}
+
+// String returns a string representation of the CHARACTER STRING value
+func (c *ASN1CharacterString) String() string {
+	return fmt.Sprintf("CHARACTER STRING{%s, %x}", identificationString(c.identification), c.stringValue)
+}
+
+// TaggedString returns a string representation with tag information
+func (c *ASN1CharacterString) TaggedString() string {
+	return fmt.Sprintf("%s CHARACTER STRING: %s, %x", c.Tag().TagString(), identificationString(c.identification), c.stringValue)
+}
+
+// DecodeCharacterString decodes an ASN1CharacterString from BER-encoded data
+func DecodeCharacterString(data []byte) (*ASN1CharacterString, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag != NewUniversalTag(TagCharacterString, true) {
+		return nil, 0, fmt.Errorf("expected CHARACTER STRING tag, got %s", asn1Value.tag.TagString())
+	}
+
+	identification, stringValue, err := decodeIdentifiedValue(asn1Value.value)
+	if err != nil {
+		return nil, 0, fmt.Errorf("CHARACTER STRING: %w", err)
+	}
+
+	return &ASN1CharacterString{identification: identification, stringValue: stringValue}, consumed, nil
+}
+
+// convertUniversalStructured converts a constructed EXTERNAL, EMBEDDED PDV or
+// CHARACTER STRING value to its typed object. Other values, and values that
+// fail to decode, are returned as the generic structure.
+func convertUniversalStructured(val *ASN1Value, structured *ASN1Structured) ASN1Object {
+	tag := val.Tag()
+	if tag.Class != 0 {
+		return structured
+	}
+
+	switch tag.Number {
+	case TagExternal:
+		if external, err := DecodeExternalValue(val.Value()); err == nil {
+			return external
+		}
+	case TagEmbeddedPDV:
+		if identification, dataValue, err := decodeIdentifiedValue(val.Value()); err == nil {
+			return &ASN1EmbeddedPDV{identification: identification, dataValue: dataValue}
+		}
+	case TagCharacterString:
+		if identification, stringValue, err := decodeIdentifiedValue(val.Value()); err == nil {
+			return &ASN1CharacterString{identification: identification, stringValue: stringValue}
+		}
+	}
+	return structured
+}
+
+// encodeIdentifiedValue encodes the identification and [2] OCTET STRING
+// shared by EMBEDDED PDV and CHARACTER STRING
+func encodeIdentifiedValue(identification *Identification, value []byte) ([]byte, error) {
+	if identification == nil {
+		return nil, fmt.Errorf("identification is required")
+	}
+	content, err := identification.encode()
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := EncodeTLV(NewContextSpecificTag(2, false), value)
+	if err != nil {
+		return nil, err
+	}
+	return append(content, encoded...), nil
+}
+
+// decodeIdentifiedValue decodes the identification and [2] OCTET STRING
+// shared by EMBEDDED PDV and CHARACTER STRING
+func decodeIdentifiedValue(data []byte) (*Identification, []byte, error) {
+	elements, err := decodeContentElements(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	// data-value-descriptor [1] is constrained to be absent
+	if len(elements) != 2 || elements[0].tag != NewContextSpecificTag(0, true) || elements[1].tag != NewContextSpecificTag(2, false) {
+		return nil, nil, fmt.Errorf("expected [0] identification and [2] value")
+	}
+	identification, err := decodeIdentification(elements[0].value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return identification, elements[1].value, nil
+}
+
+// decodeContentElements splits constructed content into its TLVs
+func decodeContentElements(data []byte) ([]*ASN1Value, error) {
+	var elements []*ASN1Value
+	for offset := 0; offset < len(data); {
+		element, consumed, err := DecodeTLV(data[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("element at offset %d: %w", offset, err)
+		}
+		elements = append(elements, element)
+		offset += consumed
+	}
+	return elements, nil
+}
+
+// contentOctets returns the content octets of an object's encoding
+func contentOctets(obj ASN1Object) ([]byte, error) {
+	encoded, err := obj.Encode()
+	if err != nil {
+		return nil, err
+	}
+	val, _, err := DecodeTLV(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return val.Value(), nil
+}
+
+// encodeTaggedOID encodes an OID with an IMPLICIT context-specific tag
+func encodeTaggedOID(number int, oid *ASN1ObjectIdentifier) ([]byte, error) {
+	if oid == nil {
+		return nil, fmt.Errorf("object identifier [%d] is required", number)
+	}
+	content, err := contentOctets(oid)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeTLV(NewContextSpecificTag(number, false), content)
+}
+
+// encodeTaggedInteger encodes an INTEGER with an IMPLICIT context-specific tag
+func encodeTaggedInteger(number int, value int64) ([]byte, error) {
+	content, err := contentOctets(NewInteger(value))
+	if err != nil {
+		return nil, err
+	}
+	return EncodeTLV(NewContextSpecificTag(number, false), content)
+}
+
+// encodeOIDPair encodes SEQUENCE { [first] OID, [second] OID } with an IMPLICIT context-specific tag
+func encodeOIDPair(number, first int, a *ASN1ObjectIdentifier, second int, b *ASN1ObjectIdentifier) ([]byte, error) {
+	encodedA, err := encodeTaggedOID(first, a)
+	if err != nil {
+		return nil, err
+	}
+	encodedB, err := encodeTaggedOID(second, b)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeTLV(NewContextSpecificTag(number, true), append(encodedA, encodedB...))
+}
+
+// decodeOIDContent decodes the content octets of an OBJECT IDENTIFIER
+func decodeOIDContent(data []byte) (*ASN1ObjectIdentifier, error) {
+	arcs, err := DecodeObjectIdentifierArcs(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewObjectIdentifierFromArcs(arcs)
+}
+
+// decodeInt64Content decodes the content octets of an INTEGER that must fit in an int64
+func decodeInt64Content(data []byte) (int64, error) {
+	value, err := DecodeIntegerValue(data)
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsInt64() {
+		return 0, fmt.Errorf("integer out of range")
+	}
+	return value.Int64(), nil
+}
+
+func oidString(oid *ASN1ObjectIdentifier) string {
+	if oid == nil {
+		return "<nil>"
+	}
+	return oid.DotNotation()
+}
+
+func identificationString(id *Identification) string {
+	if id == nil {
+		return "<nil>"
+	}
+	return id.String()
+}
@@ -0,0 +1,193 @@
+package asn1
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExternalEncoding(t *testing.T) {
+	ber := NewObjectIdentifierFromStringUnchecked("2.1.1")
+	payload := NewSequence()
+	payload.Add(NewInteger(5))
+
+	external := NewExternal(ber, payload)
+	external.SetIndirectReference(1)
+	encoded, err := external.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := []byte{0x28, 0x0E, 0x06, 0x02, 0x51, 0x01, 0x02, 0x01, 0x01, 0xA0, 0x05, 0x30, 0x03, 0x02, 0x01, 0x05}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Encode() = %x, want %x", encoded, want)
+	}
+
+	decoded, consumed, err := DecodeExternal(encoded)
+	if err != nil {
+		t.Fatalf("DecodeExternal() error = %v", err)
+	}
+	if consumed != len(encoded) || !decoded.DirectReference().Equal(ber) || decoded.Encoding() != ExternalSingleASN1Type {
+		t.Errorf("DecodeExternal() = %s", decoded)
+	}
+	if ref, ok := decoded.IndirectReference(); !ok || ref != 1 {
+		t.Errorf("IndirectReference() = %d, %v", ref, ok)
+	}
+	if seq, ok := decoded.Value().(*ASN1Structured); !ok || len(seq.Elements()) != 1 {
+		t.Errorf("Value() = %v", decoded.Value())
+	}
+
+	// The generic decoder produces the typed object
+	obj, err := Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if _, ok := convertToHighLevelObject(obj.(*ASN1Value)).(*ASN1External); !ok {
+		t.Errorf("expected ASN1External from the generic decoder")
+	}
+}
+
+func TestExternalAlternatives(t *testing.T) {
+	octets := NewExternalOctetAligned(nil, []byte{0xCA, 0xFE})
+	octets.SetIndirectReference(3)
+	octets.SetDataValueDescriptor("ACSE PDU")
+
+	arbitrary := NewExternalArbitrary(NewObjectIdentifierFromStringUnchecked("2.1.1"), NewBitString([]byte{0xF0}, 4))
+
+	for _, external := range []*ASN1External{octets, arbitrary} {
+		encoded, err := external.Encode()
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		decoded, _, err := DecodeExternal(encoded)
+		if err != nil {
+			t.Fatalf("DecodeExternal() error = %v", err)
+		}
+		if decoded.String() != external.String() {
+			t.Errorf("round-trip = %s, want %s", decoded, external)
+		}
+		reencoded, err := decoded.Encode()
+		if err != nil || !bytes.Equal(reencoded, encoded) {
+			t.Errorf("re-encoded %x, want %x", reencoded, encoded)
+		}
+	}
+	if descriptor, ok := octets.DataValueDescriptor(); !ok || descriptor != "ACSE PDU" {
+		t.Errorf("DataValueDescriptor() = %q, %v", descriptor, ok)
+	}
+
+	if _, err := NewExternal(nil, NewNull()).Encode(); err == nil {
+		t.Error("expected error without any reference")
+	}
+
+	invalid := [][]byte{
+		{0x28, 0x00},                                           // no reference or encoding
+		{0x28, 0x04, 0x02, 0x01, 0x01, 0x05},                   // truncated
+		{0x28, 0x05, 0x02, 0x01, 0x01, 0x83, 0x00},             // unknown encoding
+		{0x28, 0x07, 0x02, 0x01, 0x01, 0x81, 0x00, 0x81, 0x00}, // two encodings
+		{0x28, 0x06, 0x02, 0x01, 0x01, 0xA0, 0x01, 0x00},       // malformed single-ASN1-type
+	}
+	for _, input := range invalid {
+		if _, _, err := DecodeExternal(input); err == nil {
+			t.Errorf("DecodeExternal(%x) expected error", input)
+		}
+	}
+}
+
+func TestEmbeddedPDVAndCharacterString(t *testing.T) {
+	pdv := NewEmbeddedPDV(NewSyntaxesIdentification(
+		NewObjectIdentifierFromStringUnchecked("1.2.3"),
+		NewObjectIdentifierFromStringUnchecked("2.1.1"),
+	), []byte("hi"))
+	encoded, err := pdv.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := []byte{0x2B, 0x10, 0xA0, 0x0A, 0xA0, 0x08, 0x80, 0x02, 0x2A, 0x03, 0x81, 0x02, 0x51, 0x01, 0x82, 0x02, 'h', 'i'}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Encode() = %x, want %x", encoded, want)
+	}
+
+	str := NewCharacterString(NewFixedIdentification(), []byte("A"))
+	encoded, err = str.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want = []byte{0x3D, 0x07, 0xA0, 0x02, 0x85, 0x00, 0x82, 0x01, 'A'}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Encode() = %x, want %x", encoded, want)
+	}
+
+	transfer := NewObjectIdentifierFromStringUnchecked("2.1.1")
+	identifications := []*Identification{
+		NewSyntaxIdentification(transfer),
+		NewPresentationContextIdentification(7),
+		NewContextNegotiationIdentification(9, transfer),
+		NewTransferSyntaxIdentification(transfer),
+		NewFixedIdentification(),
+	}
+	for _, identification := range identifications {
+		encoded, err := NewEmbeddedPDV(identification, []byte{1, 2}).Encode()
+		if err != nil {
+			t.Fatalf("%s: Encode() error = %v", identification.Kind(), err)
+		}
+		decoded, _, err := DecodeEmbeddedPDV(encoded)
+		if err != nil {
+			t.Fatalf("%s: DecodeEmbeddedPDV() error = %v", identification.Kind(), err)
+		}
+		if decoded.Identification().String() != identification.String() || !bytes.Equal(decoded.DataValue(), []byte{1, 2}) {
+			t.Errorf("round-trip = %s, want %s", decoded.Identification(), identification)
+		}
+
+		encoded, _ = NewCharacterString(identification, []byte("x")).Encode()
+		if _, _, err := DecodeCharacterString(encoded); err != nil {
+			t.Errorf("%s: DecodeCharacterString() error = %v", identification.Kind(), err)
+		}
+	}
+
+	invalid := [][]byte{
+		{0x2B, 0x04, 0x82, 0x02, 'h', 'i'},                           // no identification
+		{0x2B, 0x09, 0xA0, 0x02, 0x86, 0x00, 0x82, 0x01, 'A'},        // unknown alternative
+		{0x2B, 0x09, 0xA0, 0x03, 0x85, 0x01, 0x00, 0x82, 0x00},       // fixed with content
+		{0x2B, 0x0A, 0xA0, 0x02, 0x85, 0x00, 0x81, 0x00, 0x82, 0x00}, // descriptor present
+	}
+	for _, input := range invalid {
+		if _, _, err := DecodeEmbeddedPDV(input); err == nil {
+			t.Errorf("DecodeEmbeddedPDV(%x) expected error", input)
+		}
+	}
+}
+
+type AssociationRequest struct {
+	Version     int64           `asn1:"integer"`
+	UserInfo    *ASN1External   `asn1:"external,optional,tag:30"`
+	Payload     ASN1EmbeddedPDV `asn1:"embeddedpdv"`
+	DisplayName *ASN1CharacterString
+}
+
+func TestExternalMarshaling(t *testing.T) {
+	userInfo := NewExternal(NewObjectIdentifierFromStringUnchecked("2.1.1"), NewUTF8String("hello"))
+	userInfo.SetIndirectReference(1)
+	original := &AssociationRequest{
+		Version:     1,
+		UserInfo:    userInfo,
+		Payload:     *NewEmbeddedPDV(NewPresentationContextIdentification(3), []byte{0xAB}),
+		DisplayName: NewCharacterString(NewFixedIdentification(), []byte("name")),
+	}
+
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded AssociationRequest
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.UserInfo == nil || decoded.UserInfo.String() != userInfo.String() {
+		t.Errorf("UserInfo = %v, want %v", decoded.UserInfo, userInfo)
+	}
+	if decoded.Payload.String() != original.Payload.String() {
+		t.Errorf("Payload = %v, want %v", decoded.Payload.String(), original.Payload.String())
+	}
+	if decoded.DisplayName == nil || string(decoded.DisplayName.StringValue()) != "name" {
+		t.Errorf("DisplayName = %v", decoded.DisplayName)
+	}
+}
@@ -0,0 +1,194 @@
+package asn1
+
+import (
+	"reflect"
+	"time"
+)
+
+var asn1MarshalerType = reflect.TypeOf((*ASN1Marshaler)(nil)).Elem()
+
+// fieldAcceptsElement reports whether element can be the encoding of a field,
+// by comparing its tag with the field's possible first tags (X.680 requires
+// these to be distinct for the OPTIONAL components of a SEQUENCE). Fields
+// whose tags cannot be known in advance, such as open types, accept anything.
+func fieldAcceptsElement(t reflect.Type, info *fieldInfo, element ASN1Object, opts *MarshalOptions) bool {
+	tags, ok := fieldFirstTags(t, info, opts, map[reflect.Type]bool{})
+	if !ok {
+		return true
+	}
+	got := element.Tag()
+	for _, tag := range tags {
+		if tag.Class == got.Class && tag.Number == got.Number {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldFirstTags returns the tags an encoding of the field can start with.
+// The constructed bit is not significant. It reports false when any tag is possible.
+func fieldFirstTags(t reflect.Type, info *fieldInfo, opts *MarshalOptions, seen map[reflect.Type]bool) ([]Tag, bool) {
+	if info.HasTag && opts.UseContextTags {
+		return []Tag{NewContextSpecificTag(info.Tag, false)}, true
+	}
+	if info.ChoiceSpec != "" {
+		spec, err := lookupChoiceSpec(info.ChoiceSpec)
+		if err != nil {
+			return nil, false
+		}
+		return spec.firstTags(), true
+	}
+
+	switch info.Type {
+	case "", "auto":
+		return typeFirstTags(t, opts, seen)
+	case "choice":
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if choice := lookupChoice(t); choice != nil {
+			return choice.firstTags(), true
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, false
+		}
+		return choiceFirstTags(t, opts, seen)
+	case "utctime", "generalizedtime", "rfc5280time":
+		return []Tag{NewUniversalTag(TagUTCTime, false), NewUniversalTag(TagGeneralizedTime, false)}, true
+	}
+
+	if tag, ok := universalTypeTag(info.Type); ok {
+		return []Tag{tag}, true
+	}
+	return nil, false
+}
+
+// typeFirstTags returns the tags a Go type without a type in its struct tag
+// decodes from. For integers and strings, which decode from several types,
+// the first is the one Marshal uses.
+func typeFirstTags(t reflect.Type, opts *MarshalOptions, seen map[reflect.Type]bool) ([]Tag, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return []Tag{NewUniversalTag(TagUTCTime, false), NewUniversalTag(TagGeneralizedTime, false)}, true
+	case reflect.TypeOf(Date{}):
+		return []Tag{NewUniversalTag(TagDate, false)}, true
+	case reflect.TypeOf(TimeOfDay{}):
+		return []Tag{NewUniversalTag(TagTimeOfDay, false)}, true
+	case reflect.TypeOf(time.Duration(0)):
+		return []Tag{NewUniversalTag(TagInteger, false), NewUniversalTag(TagEnumerated, false),
+			NewUniversalTag(TagDuration, false)}, true
+	case rawValueType, asn1ValueType, reflect.TypeOf(ASN1Structured{}):
+		return nil, false
+	}
+	if choice := lookupChoice(t); choice != nil {
+		return choice.firstTags(), true
+	}
+
+	// Custom marshalers are wrapped in an OCTET STRING
+	if reflect.PointerTo(t).Implements(asn1MarshalerType) {
+		return []Tag{NewUniversalTag(TagOctetString, false)}, true
+	}
+
+	// The library's object types have a fixed universal tag; the rest
+	// (CHOICE, lazy values) report an empty one
+	if reflect.PointerTo(t).Implements(asn1ObjectType) {
+		tag := reflect.New(t).Interface().(ASN1Object).Tag()
+		if tag.Class != 0 || tag.Number == 0 {
+			return nil, false
+		}
+		return []Tag{tag}, true
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return []Tag{NewUniversalTag(TagBoolean, false)}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []Tag{NewUniversalTag(TagInteger, false), NewUniversalTag(TagEnumerated, false)}, true
+	case reflect.String:
+		// Strings decode from any character string type
+		tags := make([]Tag, len(characterStringTags))
+		for i, number := range characterStringTags {
+			tags[i] = NewUniversalTag(number, false)
+		}
+		return tags, true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return []Tag{NewUniversalTag(TagOctetString, false)}, true
+		}
+		return []Tag{NewUniversalTag(TagSequence, true)}, true
+	case reflect.Map, reflect.Struct:
+		return []Tag{NewUniversalTag(TagSequence, true)}, true
+	}
+	return nil, false
+}
+
+// typeImplicitTag returns the universal tag an IMPLICIT tag replaces for a
+// Go type without a type in its struct tag: the one Marshal uses. It reports
+// false when that depends on the value, as for time.Time.
+func typeImplicitTag(t reflect.Type, opts *MarshalOptions) (Tag, bool) {
+	tags, ok := typeFirstTags(t, opts, map[reflect.Type]bool{})
+	if !ok || len(tags) == 0 {
+		return Tag{}, false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.String:
+		return tags[0], true
+	}
+	if len(tags) != 1 {
+		return Tag{}, false
+	}
+	return tags[0], true
+}
+
+// characterStringTags are the tags of the types a Go string decodes from
+var characterStringTags = []int{
+	TagUTF8String, TagNumericString, TagPrintableString, TagTeletexString,
+	TagVideotexString, TagIA5String, TagGraphicString, TagVisibleString,
+	TagGeneralString, TagUniversalString, TagBMPString, TagObjectDescriptor,
+}
+
+// choiceFirstTags returns the union of the first tags of a choice struct's alternatives
+func choiceFirstTags(t reflect.Type, opts *MarshalOptions, seen map[reflect.Type]bool) ([]Tag, bool) {
+	if seen[t] {
+		return nil, false
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	var tags []Tag
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("asn1")
+		if !field.IsExported() || tag == "-" || field.Type.Kind() != reflect.Ptr {
+			continue
+		}
+
+		info := &fieldInfo{Type: "auto"}
+		if tag != "" {
+			parsed, err := parseASN1Tag(tag)
+			if err != nil {
+				return nil, false
+			}
+			info = parsed
+		}
+		if isExtensionsField(info) {
+			continue // unknown alternatives do not make absent CHOICEs ambiguous
+		}
+
+		alternative, ok := fieldFirstTags(field.Type, info, opts, seen)
+		if !ok {
+			return nil, false
+		}
+		tags = append(tags, alternative...)
+	}
+	return tags, len(tags) > 0
+}
@@ -0,0 +1,143 @@
+package asn1
+
+import (
+	"reflect"
+	"testing"
+)
+
+type extension struct {
+	ExtnID    string `asn1:"objectidentifier"`
+	Critical  bool   `asn1:"boolean,default:false"`
+	ExtnValue []byte `asn1:"octetstring"`
+}
+
+func TestUntaggedOptionalMatchedByTag(t *testing.T) {
+	// Critical is absent: the OCTET STRING must not be consumed by it
+	data := []byte{0x30, 0x09, 0x06, 0x03, 0x55, 0x1D, 0x13, 0x04, 0x02, 0x30, 0x00}
+	var ext extension
+	if err := Unmarshal(data, &ext); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if ext.ExtnID != "2.5.29.19" || ext.Critical || !reflect.DeepEqual(ext.ExtnValue, []byte{0x30, 0x00}) {
+		t.Errorf("Unmarshal() = %+v", ext)
+	}
+
+	encoded, err := Marshal(extension{ExtnID: "2.5.29.19", Critical: true, ExtnValue: []byte{0x30, 0x00}})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var critical extension
+	if err := Unmarshal(encoded, &critical); err != nil || !critical.Critical {
+		t.Errorf("Unmarshal() = %+v, %v", critical, err)
+	}
+}
+
+type point struct {
+	X int64 `asn1:"integer"`
+}
+
+type untypedOptionals struct {
+	ID    int64                 `asn1:"integer"`
+	Where *point                `asn1:",optional"`
+	OID   *ASN1ObjectIdentifier `asn1:",optional"`
+	Label string                `asn1:",optional"`
+	Count int64                 `asn1:"integer"`
+}
+
+func TestOptionalTagsDerivedFromGoType(t *testing.T) {
+	original := untypedOptionals{ID: 1, OID: NewObjectIdentifierFromStringUnchecked("1.2.3"), Count: 4}
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded untypedOptionals
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Where != nil || decoded.Label != "" || decoded.Count != 4 || !decoded.OID.Equal(original.OID) {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+
+	original = untypedOptionals{ID: 1, Where: &point{X: 2}, Label: "x", Count: 4}
+	encoded, err = Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	decoded = untypedOptionals{}
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Where == nil || decoded.Where.X != 2 || decoded.OID != nil || decoded.Label != "x" {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+}
+
+type untypedScalars struct {
+	Level *int64  `asn1:",optional"`
+	Label *string `asn1:",optional"`
+	Data  []byte  `asn1:"octetstring"`
+}
+
+func TestUntaggedOptionalAcceptsOtherStringAndIntegerTypes(t *testing.T) {
+	// Label as a PrintableString rather than the UTF8String Marshal emits
+	data := []byte{0x30, 0x05, 0x13, 0x01, 'x', 0x04, 0x00}
+	var decoded untypedScalars
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Level != nil || decoded.Label == nil || *decoded.Label != "x" {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+
+	// Level as an ENUMERATED rather than an INTEGER
+	data = []byte{0x30, 0x05, 0x0A, 0x01, 0x02, 0x04, 0x00}
+	decoded = untypedScalars{}
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Level == nil || *decoded.Level != 2 || decoded.Label != nil {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+}
+
+type nameChoice struct {
+	Common *string `asn1:"printablestring"`
+	Serial *int64  `asn1:"integer,tag:0"`
+	Nested *innerChoice
+}
+
+type innerChoice struct {
+	Flag *bool `asn1:"boolean"`
+}
+
+func TestChoiceFirstTags(t *testing.T) {
+	info, _ := parseASN1Tag("choice,optional")
+	tags, ok := fieldFirstTags(reflect.TypeOf(&nameChoice{}), info, DefaultMarshalOptions(), map[reflect.Type]bool{})
+	if !ok {
+		t.Fatalf("fieldFirstTags() reported no tags")
+	}
+	want := []Tag{
+		NewUniversalTag(TagPrintableString, false),
+		NewContextSpecificTag(0, false),
+		NewUniversalTag(TagSequence, true),
+	}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("fieldFirstTags() = %v, want %v", tags, want)
+	}
+
+	// An absent optional CHOICE lets the next field take the element
+	type withChoice struct {
+		ID   int64      `asn1:"integer"`
+		Name nameChoice `asn1:"choice,optional"`
+		Note string     `asn1:"utf8string"`
+	}
+	data := []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x0C, 0x01, 'n'}
+	var decoded withChoice
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.ID != 1 || decoded.Note != "n" || decoded.Name.Common != nil {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+}
@@ -0,0 +1,277 @@
+package asn1
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+// fuzzSeeds are valid and malformed encodings shared by the fuzz targets
+var fuzzSeeds = []string{
+	"0101ff",                             // BOOLEAN TRUE
+	"020100",                             // INTEGER 0
+	"0209008000000000000000",             // INTEGER 2^63
+	"03020780",                           // BIT STRING with 7 unused bits
+	"0400",                               // empty OCTET STRING
+	"0500",                               // NULL
+	"06082a864886f70d0101",               // OID
+	"0d03c00102",                         // RELATIVE-OID
+	"0a0101",                             // ENUMERATED
+	"0c0568656c6c6f",                     // UTF8String
+	"130568656c6c6f",                     // PrintableString
+	"1e020041",                           // BMPString
+	"170d3233303130323033303430355a",     // UTCTime
+	"180f32303233303130323033303430355a", // GeneralizedTime
+	"1f1f0a323032332d30312d3032",         // DATE
+	"30060201010101ff",                   // SEQUENCE
+	"3180",                               // indefinite length
+	"3003020101",                         // truncated
+	"0484ffffffff",                       // length beyond the data
+	"04887fffffffffffffff",               // largest length
+	"bf8080800100",                       // long tag
+	"a003020105",                         // [0] EXPLICIT INTEGER
+	"600702010304026e6f",                 // [APPLICATION 0] bindRequest
+	"28063003060100",                     // EXTERNAL
+}
+
+func addFuzzSeeds(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		data, err := hex.DecodeString(seed)
+		if err != nil {
+			f.Fatalf("bad seed %q: %v", seed, err)
+		}
+		f.Add(data)
+	}
+}
+
+// exerciseObject calls the methods every decoded object must support
+func exerciseObject(t *testing.T, obj ASN1Object) {
+	t.Helper()
+	_ = obj.String()
+	_ = obj.TaggedString()
+	_ = obj.Tag()
+	// Decoded objects must encode again, though not necessarily identically
+	if _, err := obj.Encode(); err != nil {
+		t.Fatalf("Encode of decoded %T failed: %v", obj, err)
+	}
+}
+
+func FuzzDecodeTLV(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		val, consumed, err := DecodeTLV(data)
+		if err != nil {
+			return
+		}
+		if consumed <= 0 || consumed > len(data) {
+			t.Fatalf("consumed %d of %d bytes", consumed, len(data))
+		}
+		if !bytes.Equal(val.raw, data[:consumed]) {
+			t.Fatalf("raw encoding %x differs from input %x", val.raw, data[:consumed])
+		}
+		exerciseObject(t, val)
+	})
+}
+
+func FuzzDecodeAll(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		objects, err := DecodeAll(data)
+		if err != nil {
+			return
+		}
+		for _, obj := range objects {
+			exerciseObject(t, obj)
+		}
+
+		limited, consumed, err := DecodeWithOptions(data, &DecodeOptions{MaxDepth: 16, MaxElements: 256, MaxAllocation: 4096})
+		if err != nil {
+			return
+		}
+		if consumed > len(data) {
+			t.Fatalf("consumed %d of %d bytes", consumed, len(data))
+		}
+		exerciseObject(t, limited)
+	})
+}
+
+func FuzzDecodeFunctions(f *testing.F) {
+	addFuzzSeeds(f)
+	decoders := map[string]func([]byte) (ASN1Object, int, error){
+		"Boolean":          func(d []byte) (ASN1Object, int, error) { return DecodeBoolean(d) },
+		"Integer":          func(d []byte) (ASN1Object, int, error) { return DecodeInteger(d) },
+		"Enumerated":       func(d []byte) (ASN1Object, int, error) { return DecodeEnumerated(d) },
+		"BitString":        func(d []byte) (ASN1Object, int, error) { return DecodeBitString(d) },
+		"OctetString":      func(d []byte) (ASN1Object, int, error) { return DecodeOctetString(d) },
+		"Null":             func(d []byte) (ASN1Object, int, error) { return DecodeNull(d) },
+		"ObjectIdentifier": func(d []byte) (ASN1Object, int, error) { return DecodeObjectIdentifier(d) },
+		"RelativeOID":      func(d []byte) (ASN1Object, int, error) { return DecodeRelativeOID(d) },
+		"OIDIRI":           func(d []byte) (ASN1Object, int, error) { return DecodeOIDIRI(d) },
+		"RelativeOIDIRI":   func(d []byte) (ASN1Object, int, error) { return DecodeRelativeOIDIRI(d) },
+		"UTF8String":       func(d []byte) (ASN1Object, int, error) { return DecodeUTF8String(d) },
+		"PrintableString":  func(d []byte) (ASN1Object, int, error) { return DecodePrintableString(d) },
+		"IA5String":        func(d []byte) (ASN1Object, int, error) { return DecodeIA5String(d) },
+		"NumericString":    func(d []byte) (ASN1Object, int, error) { return DecodeNumericString(d) },
+		"VisibleString":    func(d []byte) (ASN1Object, int, error) { return DecodeVisibleString(d) },
+		"TeletexString":    func(d []byte) (ASN1Object, int, error) { return DecodeTeletexString(d) },
+		"VideotexString":   func(d []byte) (ASN1Object, int, error) { return DecodeVideotexString(d) },
+		"GraphicString":    func(d []byte) (ASN1Object, int, error) { return DecodeGraphicString(d) },
+		"GeneralString":    func(d []byte) (ASN1Object, int, error) { return DecodeGeneralString(d) },
+		"BMPString":        func(d []byte) (ASN1Object, int, error) { return DecodeBMPString(d) },
+		"UniversalString":  func(d []byte) (ASN1Object, int, error) { return DecodeUniversalString(d) },
+		"ObjectDescriptor": func(d []byte) (ASN1Object, int, error) { return DecodeObjectDescriptor(d) },
+		"UTCTime":          func(d []byte) (ASN1Object, int, error) { return DecodeUTCTime(d) },
+		"GeneralizedTime":  func(d []byte) (ASN1Object, int, error) { return DecodeGeneralizedTime(d) },
+		"Date":             func(d []byte) (ASN1Object, int, error) { return DecodeDate(d) },
+		"TimeOfDay":        func(d []byte) (ASN1Object, int, error) { return DecodeTimeOfDay(d) },
+		"DateTime":         func(d []byte) (ASN1Object, int, error) { return DecodeDateTime(d) },
+		"Duration":         func(d []byte) (ASN1Object, int, error) { return DecodeDuration(d) },
+		"Time":             func(d []byte) (ASN1Object, int, error) { return DecodeTime(d) },
+		"External":         func(d []byte) (ASN1Object, int, error) { return DecodeExternal(d) },
+		"EmbeddedPDV":      func(d []byte) (ASN1Object, int, error) { return DecodeEmbeddedPDV(d) },
+		"CharacterString":  func(d []byte) (ASN1Object, int, error) { return DecodeCharacterString(d) },
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for name, decode := range decoders {
+			obj, consumed, err := decode(data)
+			if err != nil {
+				continue
+			}
+			if consumed <= 0 || consumed > len(data) {
+				t.Fatalf("Decode%s consumed %d of %d bytes", name, consumed, len(data))
+			}
+			exerciseObject(t, obj)
+		}
+
+		// The content decoders take the value without its tag and length
+		_, _, _ = DecodeBitStringValue(data)
+		_, _ = DecodeBooleanValue(data)
+		_, _ = DecodeIntegerValue(data)
+		_, _ = DecodeEnumeratedValue(data)
+		_, _ = DecodeObjectIdentifierValue(data)
+		_, _ = DecodeObjectIdentifierArcs(data)
+		_, _ = DecodeRelativeOIDValue(data)
+		_, _ = DecodeRelativeOIDArcs(data)
+		_, _ = DecodeDateValue(data)
+		_, _ = DecodeTimeOfDayValue(data)
+		_, _ = DecodeDateTimeValue(data)
+		_, _ = DecodeDurationValue(data)
+		_, _ = DecodeExternalValue(data)
+	})
+}
+
+// fuzzExtension and fuzzCertificate cover the common field kinds: integers,
+// OIDs, strings, times, optional, explicit and implicit fields, SEQUENCE OF
+// and extensions
+type fuzzExtension struct {
+	ID       []int  `asn1:"objectidentifier"`
+	Critical bool   `asn1:"boolean,default:false"`
+	Value    []byte `asn1:"octetstring"`
+}
+
+type fuzzCertificate struct {
+	Version    int64           `asn1:"integer,tag:0,explicit,default:0"`
+	Serial     int64           `asn1:"integer"`
+	Issuer     string          `asn1:"printablestring"`
+	NotBefore  time.Time       `asn1:"utctime"`
+	NotAfter   time.Time       `asn1:"generalizedtime"`
+	Flags      *ASN1BitString  `asn1:",optional"`
+	Nickname   *string         `asn1:"utf8string,tag:1,implicit,optional"`
+	Extensions []fuzzExtension `asn1:"sequence,tag:3,explicit,optional"`
+	Rest       []RawValue      `asn1:"..."`
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	addFuzzSeeds(f)
+	nickname := "fuzz"
+	cert := fuzzCertificate{
+		Version:   2,
+		Serial:    12345,
+		Issuer:    "Example CA",
+		NotBefore: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		NotAfter:  time.Date(2033, 1, 2, 3, 4, 5, 0, time.UTC),
+		Flags:     NewBitString([]byte{0x80}, 7),
+		Nickname:  &nickname,
+		Extensions: []fuzzExtension{
+			{ID: []int{2, 5, 29, 19}, Critical: true, Value: []byte{0x30, 0x00}},
+		},
+	}
+	if data, err := Marshal(cert); err == nil {
+		f.Add(data)
+	} else {
+		f.Fatalf("Marshal failed: %v", err)
+	}
+	if data, err := Marshal(ldapMessage{MessageID: 1, ProtocolOp: &unbindRequest{}}); err == nil {
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		targets := []interface{}{
+			new(fuzzCertificate),
+			new(ldapMessage),
+			new(callInfoV1),
+			new(errorEnvelope),
+			new([]int64),
+			new(map[string]int64),
+			new(RawValue),
+		}
+		for _, target := range targets {
+			if err := Unmarshal(data, target); err != nil {
+				continue
+			}
+			// Whatever decodes must encode again
+			if _, err := Marshal(target); err != nil {
+				t.Fatalf("Marshal of decoded %T failed: %v", target, err)
+			}
+		}
+	})
+}
+
+// fuzzStrings has a field of each string type; values a type cannot hold
+// must fail to marshal rather than panic
+type fuzzStrings struct {
+	UTF8      string `asn1:"utf8string"`
+	Printable string `asn1:"printablestring"`
+	IA5       string `asn1:"ia5string"`
+	Numeric   string `asn1:"numericstring"`
+	Visible   string `asn1:"visiblestring"`
+	Teletex   string `asn1:"teletexstring"`
+	BMP       string `asn1:"bmpstring"`
+	Universal string `asn1:"universalstring"`
+	Auto      string
+}
+
+func FuzzMarshal(f *testing.F) {
+	f.Add("Example CA", int64(1), []byte{0x01})
+	f.Add("a@b", int64(-1), []byte{})
+	f.Add("\xff\xfe", int64(0), []byte(nil))
+	f.Add("\U0001F600", int64(1)<<62, []byte{0x00, 0xFF})
+
+	f.Fuzz(func(t *testing.T, s string, n int64, b []byte) {
+		strs := fuzzStrings{s, s, s, s, s, s, s, s, s}
+		if data, err := Marshal(strs); err == nil {
+			var got fuzzStrings
+			if err := Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal of %x failed: %v", data, err)
+			}
+			if got != strs {
+				t.Fatalf("round trip of %q gave %+v", s, got)
+			}
+		}
+
+		ext := fuzzExtension{ID: []int{1, 3, int(n & 0x7FFFFFFF)}, Critical: n < 0, Value: b}
+		data, err := Marshal(ext)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var got fuzzExtension
+		if err := Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal of %x failed: %v", data, err)
+		}
+		if !bytes.Equal(got.Value, ext.Value) || got.Critical != ext.Critical || got.ID[2] != ext.ID[2] {
+			t.Fatalf("round trip of %+v gave %+v", ext, got)
+		}
+	})
+}
@@ -61,56 +61,7 @@ func (i *ASN1Integer) Encode() ([]byte, error) {
 }
 
 func (i *ASN1Integer) encodeIntegerValue() []byte {
-	if i.value.Sign() == 0 {
-		return []byte{0x00}
-	}
-
-	bytes := i.value.Bytes()
-	
-	// For positive numbers, if the high bit is set, prepend a zero byte
-	// to avoid confusion with negative numbers in two's complement
-	if i.value.Sign() > 0 && len(bytes) > 0 && (bytes[0]&0x80) != 0 {
-		bytes = append([]byte{0x00}, bytes...)
-	}
-	
-	// For negative numbers, use two's complement representation
-	if i.value.Sign() < 0 {
-		// For negative numbers, we need to compute the minimum number of bytes
-		// to represent the number in two's complement form
-		
-		// Special case for small negative numbers that fit in one byte
-		if i.value.Cmp(big.NewInt(-128)) >= 0 && i.value.Cmp(big.NewInt(0)) < 0 {
-			val := i.value.Int64()
-			return []byte{byte(val)}
-		}
-		
-		// For larger negative numbers, calculate two's complement
-		abs := new(big.Int).Abs(i.value)
-		
-		// Find minimum number of bits needed
-		bitLen := abs.BitLen()
-		// We need at least bitLen + 1 bits for the sign
-		if bitLen%8 == 0 {
-			bitLen += 8
-		} else {
-			bitLen = ((bitLen / 8) + 1) * 8
-		}
-		byteLen := bitLen / 8
-		
-		// Create modulus (2^(8*byteLen))
-		modulus := new(big.Int).Lsh(big.NewInt(1), uint(byteLen*8))
-		
-		// Calculate two's complement: modulus + value (since value is negative)
-		twosComp := new(big.Int).Add(modulus, i.value)
-		bytes = twosComp.Bytes()
-		
-		// Pad with 0xFF bytes if needed to maintain the correct length
-		for len(bytes) < byteLen {
-			bytes = append([]byte{0xFF}, bytes...)
-		}
-	}
-	
-	return bytes
+	return encodeIntegerValue(i.value)
 }
 
 // String returns a string representation of the integer
@@ -0,0 +1,141 @@
+package asn1
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Lazy is a struct field whose decoding is deferred. Unmarshal stores the
+// verbatim encoding of the element without converting it to T; Get decodes
+// it on first use and caches the result. Marshal writes the stored encoding
+// unchanged unless Set has replaced the value, so a message can be forwarded
+// without its body ever being decoded.
+//
+// Lazy fields need no type in their struct tag; context tags and optional
+// work as for any other field. A Lazy must not be shared between goroutines
+// before its first Get.
+type Lazy[T any] struct {
+	raw     []byte
+	opts    *MarshalOptions // options of the Unmarshal that stored raw
+	value   T
+	decoded bool
+	err     error
+}
+
+// NewLazy creates a Lazy holding an already decoded value
+func NewLazy[T any](value T) Lazy[T] {
+	return Lazy[T]{value: value, decoded: true}
+}
+
+// Get decodes the stored encoding into T the first time it is called, with
+// the options of the Unmarshal that stored it
+func (l *Lazy[T]) Get() (T, error) {
+	if !l.decoded {
+		l.decoded = true
+		if l.raw == nil {
+			l.err = fmt.Errorf("lazy value is empty")
+		} else {
+			l.err = UnmarshalWithOptions(l.raw, &l.value, l.opts)
+		}
+	}
+	return l.value, l.err
+}
+
+// Set replaces the value; the original encoding is discarded
+func (l *Lazy[T]) Set(value T) {
+	*l = Lazy[T]{value: value, decoded: true}
+}
+
+// Raw returns the encoding the value was unmarshaled from, or nil if it was
+// created with NewLazy or Set
+func (l *Lazy[T]) Raw() []byte {
+	if l.raw == nil {
+		return nil
+	}
+	result := make([]byte, len(l.raw))
+	copy(result, l.raw)
+	return result
+}
+
+// Tag returns the tag of the stored or marshaled value
+func (l *Lazy[T]) Tag() Tag {
+	encoded := l.raw
+	if encoded == nil {
+		var err error
+		if encoded, err = l.Encode(); err != nil {
+			return Tag{}
+		}
+	}
+	tag, _, err := DecodeTag(encoded)
+	if err != nil {
+		return Tag{}
+	}
+	return tag
+}
+
+// Encode returns the stored encoding, or marshals the value if it was set
+func (l *Lazy[T]) Encode() ([]byte, error) {
+	if l.raw != nil {
+		return l.Raw(), nil
+	}
+	if !l.decoded {
+		return nil, fmt.Errorf("lazy value is empty")
+	}
+	return Marshal(l.value)
+}
+
+// String returns a string representation of the lazy value
+func (l *Lazy[T]) String() string {
+	if l.raw != nil && !l.decoded {
+		return fmt.Sprintf("Lazy{%d bytes}", len(l.raw))
+	}
+	return fmt.Sprintf("Lazy{%v}", l.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (l *Lazy[T]) TaggedString() string {
+	return fmt.Sprintf("%s %s", l.Tag().TagString(), l.String())
+}
+
+// setEncoding stores the encoding of a decoded element, and the options it
+// was unmarshaled with, for later decoding
+func (l *Lazy[T]) setEncoding(obj ASN1Object, opts *MarshalOptions) error {
+	encoded, err := unmarshaledEncoding(obj, opts)
+	if err != nil {
+		return fmt.Errorf("failed to capture lazy value: %w", err)
+	}
+	*l = Lazy[T]{}
+	l.raw = make([]byte, len(encoded))
+	copy(l.raw, encoded)
+
+	// The decoding state belongs to the enclosing input
+	captured := *opts
+	captured.state = nil
+	l.opts = &captured
+	return nil
+}
+
+// marshalWithOptions returns the stored encoding, or marshals the value
+// with opts if it was set
+func (l *Lazy[T]) marshalWithOptions(opts *MarshalOptions) (ASN1Object, error) {
+	if l.raw != nil {
+		return l, nil
+	}
+	if !l.decoded {
+		return nil, fmt.Errorf("lazy value is empty")
+	}
+	return marshalValue(reflect.ValueOf(&l.value).Elem(), opts)
+}
+
+// lazyDecoder is implemented by every instantiation of Lazy
+type lazyDecoder interface {
+	setEncoding(obj ASN1Object, opts *MarshalOptions) error
+	marshalWithOptions(opts *MarshalOptions) (ASN1Object, error)
+}
+
+var lazyDecoderType = reflect.TypeOf((*lazyDecoder)(nil)).Elem()
+
+// isLazy reports whether v holds a Lazy value
+func isLazy(v reflect.Value) bool {
+	return v.Kind() == reflect.Struct && reflect.PointerTo(v.Type()).Implements(lazyDecoderType)
+}
@@ -0,0 +1,141 @@
+package asn1
+
+import (
+	"bytes"
+	"testing"
+)
+
+type envelopeHeader struct {
+	Destination string `asn1:"utf8string"`
+	Priority    int64  `asn1:"integer"`
+}
+
+type envelopeBody struct {
+	Flag    bool   `asn1:"boolean"`
+	Payload []byte `asn1:"octetstring"`
+}
+
+type envelope struct {
+	Header envelopeHeader     `asn1:"sequence"`
+	Body   Lazy[envelopeBody] `asn1:"sequence,tag:1"`
+}
+
+func TestLazyDefersDecoding(t *testing.T) {
+	original := envelope{
+		Header: envelopeHeader{Destination: "queue", Priority: 2},
+		Body:   NewLazy(envelopeBody{Flag: true, Payload: []byte{1, 2, 3}}),
+	}
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded envelope
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Header.Destination != "queue" || decoded.Header.Priority != 2 {
+		t.Errorf("Header = %+v", decoded.Header)
+	}
+	if decoded.Body.Raw() == nil || decoded.Body.decoded {
+		t.Fatalf("Body should hold undecoded bytes")
+	}
+
+	body, err := decoded.Body.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !body.Flag || !bytes.Equal(body.Payload, []byte{1, 2, 3}) {
+		t.Errorf("Get() = %+v", body)
+	}
+
+	// Forwarding reproduces the input exactly
+	forwarded, err := Marshal(decoded)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !bytes.Equal(forwarded, encoded) {
+		t.Errorf("Marshal() = %x, want %x", forwarded, encoded)
+	}
+}
+
+func TestLazyForwardsNonCanonicalBytes(t *testing.T) {
+	type message struct {
+		ID   int64              `asn1:"integer"`
+		Body Lazy[envelopeBody] `asn1:"sequence"`
+	}
+
+	// BOOLEAN TRUE as 0x01 would re-encode as 0xFF
+	data := []byte{0x30, 0x0C, 0x02, 0x01, 0x01, 0x30, 0x07, 0x01, 0x01, 0x01, 0x04, 0x02, 0xAA, 0xBB}
+	var decoded message
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	forwarded, err := Marshal(decoded)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !bytes.Equal(forwarded, data) {
+		t.Errorf("Marshal() = %x, want %x", forwarded, data)
+	}
+
+	decoded.Body.Set(envelopeBody{Flag: false, Payload: []byte{0xAA}})
+	if decoded.Body.Raw() != nil {
+		t.Errorf("Set() should discard the original encoding")
+	}
+	changed, err := Marshal(decoded)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x0B, 0x02, 0x01, 0x01, 0x30, 0x06, 0x01, 0x01, 0x00, 0x04, 0x01, 0xAA}
+	if !bytes.Equal(changed, want) {
+		t.Errorf("Marshal() = %x, want %x", changed, want)
+	}
+
+	var empty Lazy[int64]
+	if _, err := empty.Get(); err == nil {
+		t.Errorf("expected error from empty Lazy")
+	}
+}
+
+type taggedBody struct {
+	Count int64  `asn1:"integer,tag:0"`
+	Name  string `asn1:"utf8string,tag:1"`
+}
+
+type taggedEnvelope struct {
+	ID   int64            `asn1:"integer"`
+	Body Lazy[taggedBody] `asn1:"sequence"`
+}
+
+func TestLazyKeepsUnmarshalOptions(t *testing.T) {
+	opts := DefaultMarshalOptions()
+	opts.ExplicitTags = true
+
+	encoded, err := MarshalWithOptions(taggedEnvelope{ID: 1, Body: NewLazy(taggedBody{Count: 5, Name: "five"})}, opts)
+	if err != nil {
+		t.Fatalf("MarshalWithOptions() error = %v", err)
+	}
+
+	var decoded taggedEnvelope
+	if err := UnmarshalWithOptions(encoded, &decoded, opts); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+	if got := decoded.Body.Tag(); got != NewUniversalTag(TagSequence, true) {
+		t.Errorf("Tag() = %s, want SEQUENCE", got.TagString())
+	}
+
+	// Explicit tags are only understood with the options the parent used
+	body, err := decoded.Body.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if body.Count != 5 || body.Name != "five" {
+		t.Errorf("Get() = %+v", body)
+	}
+
+	var plain taggedBody
+	if err := Unmarshal(decoded.Body.Raw(), &plain); err == nil && plain.Count == 5 {
+		t.Error("default options should not decode the explicitly tagged body")
+	}
+}
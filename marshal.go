@@ -1,8 +1,11 @@
 package asn1
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,12 +15,32 @@ import (
 type MarshalOptions struct {
 	// UseContextTags controls whether to use context-specific tags for optional fields
 	UseContextTags bool
+	// TimeOptions controls fractional-second precision and zone handling for time fields
+	TimeOptions TimeOptions
+	// DefaultTimeType is the type used for time.Time values without a type in their
+	// struct tag: "utctime", "generalizedtime" or "rfc5280time". Empty means "utctime";
+	// DefaultMarshalOptions uses "rfc5280time".
+	DefaultTimeType string
+	// ExplicitTags makes context-specific tags EXPLICIT unless a field says
+	// implicit, like a module with EXPLICIT TAGS. The default is IMPLICIT.
+	ExplicitTags bool
+	// RejectTrailingData makes Unmarshal fail when bytes follow the decoded value
+	RejectTrailingData bool
+	// RejectUnknownElements makes Unmarshal fail when a SEQUENCE has elements
+	// left over after its last field, unless the struct has an extensions field
+	RejectUnknownElements bool
+	// Limits bounds the resources Unmarshal may use on untrusted input.
+	// Nil means DefaultDecodeOptions.
+	Limits *DecodeOptions
+
+	state *decodeState // the input being unmarshaled, for locating errors
 }
 
 // DefaultMarshalOptions returns default marshaling options
 func DefaultMarshalOptions() *MarshalOptions {
 	return &MarshalOptions{
-		UseContextTags: true,
+		UseContextTags:  true,
+		DefaultTimeType: "rfc5280time",
 	}
 }
 
@@ -42,73 +65,51 @@ func Unmarshal(data []byte, v interface{}) error {
 
 // UnmarshalWithOptions decodes ASN.1 data into a Go struct using struct tags with custom options
 func UnmarshalWithOptions(data []byte, v interface{}, opts *MarshalOptions) error {
-	// Decode the ASN.1 data first
-	asn1Value, _, err := DecodeTLV(data)
+	rest, err := UnmarshalRestWithOptions(data, v, opts)
 	if err != nil {
-		return fmt.Errorf("failed to decode ASN.1 data: %w", err)
+		return err
 	}
+	if opts.RejectTrailingData && len(rest) > 0 {
+		return &SyntaxError{Offset: len(data) - len(rest), Msg: fmt.Sprintf("%d bytes of trailing data after ASN.1 value", len(rest))}
+	}
+	return nil
+}
 
-	// Convert ASN1Value to higher-level object if it's a structured type
-	var obj ASN1Object = asn1Value
-	if asn1Value.Tag().Constructed {
-		// It's a structured type, create an ASN1Structured
-		structured := NewStructured(asn1Value.Tag())
-
-		// Parse the content to extract individual elements
-		content := asn1Value.Value()
-		offset := 0
+// UnmarshalRest decodes the first ASN.1 value in data into a Go struct and
+// returns the bytes after it, so that concatenated values can be parsed in turn
+func UnmarshalRest(data []byte, v interface{}) (rest []byte, err error) {
+	return UnmarshalRestWithOptions(data, v, DefaultMarshalOptions())
+}
 
-		for offset < len(content) {
-			elementValue, consumed, err := DecodeTLV(content[offset:])
-			if err != nil {
-				return fmt.Errorf("failed to decode element: %w", err)
-			}
+// UnmarshalRestWithOptions is UnmarshalRest with custom options.
+// RejectTrailingData does not apply, as the trailing bytes are returned.
+func UnmarshalRestWithOptions(data []byte, v interface{}, opts *MarshalOptions) (rest []byte, err error) {
+	// Decode the ASN.1 data first, recording where each object starts
+	state := newDecodeState(opts.Limits)
+	state.offsets = make(map[ASN1Object]int)
+	obj, consumed, err := state.decode(data)
+	if err != nil {
+		return nil, err
+	}
 
-			// Convert element to higher-level object
-			element := convertToHighLevelObject(elementValue)
-			structured.Add(element)
-			offset += consumed
-		}
+	decodeOpts := *opts
+	decodeOpts.state = state
+	opts = &decodeOpts
 
-		obj = structured
-	} else {
-		// It's a primitive type, convert to specific object
-		obj = convertToHighLevelObject(asn1Value)
+	if err := unmarshalValue(obj, reflect.ValueOf(v).Elem(), opts); err != nil {
+		return nil, atPath(err, "", obj, opts)
 	}
-
-	return unmarshalValue(obj, reflect.ValueOf(v).Elem(), opts)
+	return data[consumed:], nil
 }
 
 // convertToHighLevelObject converts an ASN1Value to its appropriate higher-level object
 func convertToHighLevelObject(val *ASN1Value) ASN1Object {
-	tag := val.Tag()
-
-	if tag.Constructed {
-		// It's a structured type
-		structured := NewStructured(tag)
-
-		// Parse the content to extract individual elements
-		content := val.Value()
-		offset := 0
-
-		for offset < len(content) {
-			elementValue, consumed, err := DecodeTLV(content[offset:])
-			if err != nil {
-				// If we can't parse the content, return as ASN1Value
-				return val
-			}
-
-			// Recursively convert elements
-			element := convertToHighLevelObject(elementValue)
-			structured.Add(element)
-			offset += consumed
-		}
-
-		return structured
-	} else {
-		// It's a primitive type, convert to specific object
-		return convertPrimitiveValue(val)
+	obj, err := newDecodeState(nil).convert(val, 0, 1)
+	if err != nil {
+		// Too deeply nested to convert, return as ASN1Value
+		return val
 	}
+	return obj
 }
 
 // convertPrimitiveValue converts an ASN1Value to its specific typed object
@@ -140,14 +141,22 @@ func convertPrimitiveValue(val *ASN1Value) ASN1Object {
 			return val
 		}
 		return NewIntegerFromBigInt(integer)
+	case TagEnumerated:
+		enumerated, err := DecodeEnumeratedValue(value)
+		if err != nil {
+			return val
+		}
+		return NewEnumeratedFromBigInt(enumerated)
 	case TagOctetString:
 		return NewOctetString(value)
-	case TagUTF8String:
-		return NewUTF8String(string(value))
-	case TagPrintableString:
-		return NewPrintableString(string(value))
-	case TagIA5String:
-		return NewIA5String(string(value))
+	case TagUTF8String, TagNumericString, TagPrintableString, TagTeletexString, TagVideotexString,
+		TagIA5String, TagGraphicString, TagVisibleString, TagGeneralString, TagUniversalString, TagBMPString,
+		TagObjectDescriptor:
+		str, err := decodeStringObject(tag.Number, value)
+		if err != nil {
+			return val
+		}
+		return str
 	case TagUTCTime:
 		// Re-encode as proper TLV and use the decoder
 		tlvData, err := EncodeTLV(tag, value)
@@ -170,18 +179,77 @@ func convertPrimitiveValue(val *ASN1Value) ASN1Object {
 			return val
 		}
 		return genTime
+	case TagDate:
+		date, err := DecodeDateValue(value)
+		if err != nil {
+			return val
+		}
+		return NewDate(date)
+	case TagTimeOfDay:
+		timeOfDay, err := DecodeTimeOfDayValue(value)
+		if err != nil {
+			return val
+		}
+		return NewTimeOfDay(timeOfDay)
+	case TagDateTime:
+		dateTime, err := DecodeDateTimeValue(value)
+		if err != nil {
+			return val
+		}
+		return NewDateTime(dateTime)
+	case TagDuration:
+		duration, err := DecodeDurationValue(value)
+		if err != nil {
+			return val
+		}
+		return NewDuration(duration)
+	case TagTime:
+		if len(value) == 0 || !isVisibleString(string(value)) {
+			return val
+		}
+		return NewTime(string(value))
+	case TagOID:
+		arcs, err := DecodeObjectIdentifierArcs(value)
+		if err != nil {
+			return val
+		}
+		return &ASN1ObjectIdentifier{arcs: arcs}
+	case TagRelativeOID:
+		arcs, err := DecodeRelativeOIDArcs(value)
+		if err != nil {
+			return val
+		}
+		return &ASN1RelativeOID{arcs: arcs}
+	case TagOIDIRI:
+		iri, err := NewOIDIRI(string(value))
+		if err != nil {
+			return val
+		}
+		return iri
+	case TagRelativeOIDIRI:
+		iri, err := NewRelativeOIDIRI(string(value))
+		if err != nil {
+			return val
+		}
+		return iri
 	default:
 		return val
 	}
 } // fieldInfo represents parsed ASN.1 field information from struct tags
 type fieldInfo struct {
-	Name      string
-	Type      string
-	Optional  bool
-	Tag       int
-	HasTag    bool
-	Omitempty bool
-	Explicit  bool // If true, use explicit tagging (wrap); if false, use implicit tagging (replace)
+	Name       string
+	Type       string
+	Optional   bool
+	Tag        int
+	HasTag     bool
+	Omitempty  bool
+	Explicit   bool   // If true, use explicit tagging (wrap); if false, use implicit tagging (replace)
+	Implicit   bool   // Set by the implicit option, overriding MarshalOptions.ExplicitTags
+	ElemType   string // Type of slice elements and map values, from elem:TYPE
+	KeyType    string // Type of map keys, from key:TYPE
+	Default    string // DEFAULT value, from default:VALUE
+	HasDefault bool
+	ChoiceSpec string // Name of a registered ChoiceSpec, from choicespec:NAME
 }
 
 // parseASN1Tag parses an ASN.1 struct tag
@@ -198,6 +266,12 @@ func parseASN1Tag(tag string) (*fieldInfo, error) {
 	info := &fieldInfo{
 		Type: strings.ToLower(strings.TrimSpace(parts[0])),
 	}
+	if info.Type == "" {
+		// Options without a type, such as ",optional", use the Go type
+		info.Type = "auto"
+	} else if info.Type == "..." {
+		info.Type = "extensions"
+	}
 
 	// Parse options
 	for i := 1; i < len(parts); i++ {
@@ -209,6 +283,8 @@ func parseASN1Tag(tag string) (*fieldInfo, error) {
 			info.Omitempty = true
 		case part == "explicit":
 			info.Explicit = true
+		case part == "implicit":
+			info.Implicit = true
 		case strings.HasPrefix(part, "tag:"):
 			tagStr := strings.TrimPrefix(part, "tag:")
 			tagNum, err := strconv.Atoi(tagStr)
@@ -217,12 +293,42 @@ func parseASN1Tag(tag string) (*fieldInfo, error) {
 			}
 			info.Tag = tagNum
 			info.HasTag = true
+		case strings.HasPrefix(part, "elem:"):
+			info.ElemType = strings.ToLower(strings.TrimPrefix(part, "elem:"))
+		case strings.HasPrefix(part, "key:"):
+			info.KeyType = strings.ToLower(strings.TrimPrefix(part, "key:"))
+		case strings.HasPrefix(part, "default:"):
+			// A component with a DEFAULT value is also OPTIONAL
+			info.Default = strings.TrimPrefix(part, "default:")
+			info.HasDefault = true
+			info.Optional = true
+		case strings.HasPrefix(part, "choicespec:"):
+			info.ChoiceSpec = strings.TrimPrefix(part, "choicespec:")
+			if info.Type == "auto" {
+				info.Type = "choice"
+			}
 		}
 	}
 
+	if info.Explicit && info.Implicit {
+		return nil, fmt.Errorf("explicit and implicit cannot both be set")
+	}
+
 	return info, nil
 }
 
+// isEmptyValue reports whether a field counts as empty for omitempty and
+// optional: nil, zero, or a string, slice or map of length zero
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
 // marshalValue converts a Go value to an ASN.1 object based on its type and tags
 func marshalValue(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 	// Check if the value implements custom marshaler interface
@@ -234,6 +340,15 @@ func marshalValue(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 		return obj, nil
 	}
 
+	// Values that are already ASN.1 objects encode as themselves
+	if obj, ok := asASN1Object(v); ok {
+		return obj, nil
+	}
+	if v.Type() == rawValueType {
+		raw := v.Interface().(RawValue)
+		return &rawObject{raw: &raw}, nil
+	}
+
 	// Handle pointer types
 	if v.Kind() == reflect.Ptr {
 		if v.IsNil() {
@@ -250,10 +365,12 @@ func marshalValue(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 			// []byte -> OCTET STRING
 			return NewOctetString(v.Bytes()), nil
 		}
-		return marshalSlice(v, opts)
+		return marshalSlice(v, "", opts)
+	case reflect.Map:
+		return marshalMap(v, "", "", opts)
 	case reflect.String:
 		// Default to UTF8String, but this should be overridden by tags
-		return NewUTF8String(v.String()), nil
+		return newStringObject(TagUTF8String, v.String())
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return NewInteger(v.Int()), nil
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -262,6 +379,9 @@ func marshalValue(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 		return NewBoolean(v.Bool()), nil
 	case reflect.Interface:
 		// Handle interface{} for CHOICE types
+		if choice := lookupChoice(v.Type()); choice != nil {
+			return choice.marshal(v, opts)
+		}
 		if v.IsNil() {
 			return nil, fmt.Errorf("nil interface cannot be marshaled")
 		}
@@ -275,12 +395,18 @@ func marshalValue(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 func marshalStruct(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 	if v.Type() == reflect.TypeOf(time.Time{}) {
 		// Special handling for time.Time
-		t := v.Interface().(time.Time)
-		return NewUTCTime(t), nil
+		return marshalDefaultTime(v.Interface().(time.Time), opts)
+	}
+	switch value := v.Interface().(type) {
+	case Date:
+		return NewDate(value), nil
+	case TimeOfDay:
+		return NewTimeOfDay(value), nil
 	}
 
 	t := v.Type()
 	seq := NewSequence()
+	var extensions []ASN1Object
 
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
@@ -309,6 +435,23 @@ func marshalStruct(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 			// Default behavior without tags
 			info = &fieldInfo{Type: "auto"}
 		}
+		if isRawContentField(fieldType, info) {
+			continue // Raw content is only filled in by Unmarshal
+		}
+		if isExtensionsField(info) {
+			// Unknown extensions follow the known components
+			if extensions, err = marshalExtensions(field); err != nil {
+				return nil, fmt.Errorf("field %s: %w", fieldType.Name, err)
+			}
+			continue
+		}
+
+		// Skip empty omitempty and optional fields. Fields with a DEFAULT
+		// are compared against it below instead.
+		omittable := info.Omitempty || (info.Optional && !info.HasDefault)
+		if omittable && isEmptyValue(field) {
+			continue
+		}
 
 		// Handle optional fields (pointers)
 		if field.Kind() == reflect.Ptr && field.IsNil() {
@@ -318,6 +461,17 @@ func marshalStruct(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 			return nil, fmt.Errorf("required field %s is nil", fieldType.Name)
 		}
 
+		// DER omits components equal to their DEFAULT value
+		if info.HasDefault {
+			isDefault, err := isDefaultValue(field, info)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", fieldType.Name, err)
+			}
+			if isDefault {
+				continue
+			}
+		}
+
 		// Marshal the field value
 		var obj ASN1Object
 		if info.Type == "auto" {
@@ -331,32 +485,31 @@ func marshalStruct(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 
 		// Apply context-specific tag if specified
 		if info.HasTag && opts.UseContextTags {
-			if info.Explicit {
-				// EXPLICIT tagging: wrap the object with context-specific tag
-				// The wrapper is always constructed for EXPLICIT tagging
-				contextTag := NewContextSpecificTag(info.Tag, true)
-				wrapped := NewStructured(contextTag)
-				wrapped.Add(obj)
-				obj = wrapped
-			} else {
-				// IMPLICIT tagging: replace the object's tag with context-specific tag
-				obj = replaceTag(obj, info.Tag)
+			explicit, err := isExplicitTag(field.Type(), info, opts)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", fieldType.Name, err)
 			}
+			obj = applyContextTag(obj, info.Tag, explicit)
 		}
 
 		seq.Add(obj)
 	}
 
+	for _, extension := range extensions {
+		seq.Add(extension)
+	}
+
 	return seq, nil
 }
 
-// marshalSlice converts a Go slice to an ASN.1 SEQUENCE OF
-func marshalSlice(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
+// marshalSlice converts a Go slice to an ASN.1 SEQUENCE OF.
+// elemType is the ASN.1 type of the elements, or empty to derive it from the Go type.
+func marshalSlice(v reflect.Value, elemType string, opts *MarshalOptions) (ASN1Object, error) {
 	seq := NewSequence()
 
 	for i := 0; i < v.Len(); i++ {
 		elem := v.Index(i)
-		obj, err := marshalValue(elem, opts)
+		obj, err := marshalElement(elem, elemType, opts)
 		if err != nil {
 			return nil, fmt.Errorf("slice element %d: %w", i, err)
 		}
@@ -366,6 +519,70 @@ func marshalSlice(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 	return seq, nil
 }
 
+// marshalMap converts a Go map to a SEQUENCE OF SEQUENCE { key, value }.
+// Entries are sorted by their encoding so that the output is deterministic.
+func marshalMap(v reflect.Value, keyType, elemType string, opts *MarshalOptions) (ASN1Object, error) {
+	type mapEntry struct {
+		obj     ASN1Object
+		encoded []byte
+	}
+	entries := make([]mapEntry, 0, v.Len())
+
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := marshalElement(iter.Key(), keyType, opts)
+		if err != nil {
+			return nil, fmt.Errorf("map key %v: %w", iter.Key(), err)
+		}
+		value, err := marshalElement(iter.Value(), elemType, opts)
+		if err != nil {
+			return nil, fmt.Errorf("map value for key %v: %w", iter.Key(), err)
+		}
+
+		entry := NewSequence()
+		entry.Add(key)
+		entry.Add(value)
+		encoded, err := entry.Encode()
+		if err != nil {
+			return nil, fmt.Errorf("map entry for key %v: %w", iter.Key(), err)
+		}
+		entries = append(entries, mapEntry{obj: entry, encoded: encoded})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].encoded, entries[j].encoded) < 0
+	})
+
+	seq := NewSequence()
+	for _, entry := range entries {
+		seq.Add(entry.obj)
+	}
+	return seq, nil
+}
+
+// marshalElement marshals a slice element, map key or map value using the
+// element type hint from the struct tag if there is one
+func marshalElement(v reflect.Value, asn1Type string, opts *MarshalOptions) (ASN1Object, error) {
+	if asn1Type == "" {
+		return marshalValue(v, opts)
+	}
+	return marshalTypedValue(v, &fieldInfo{Type: asn1Type}, opts)
+}
+
+// marshalDefaultTime encodes a time.Time whose struct tag does not name a time type
+func marshalDefaultTime(t time.Time, opts *MarshalOptions) (ASN1Object, error) {
+	switch opts.DefaultTimeType {
+	case "", "utctime":
+		return NewUTCTimeWithOptions(t, opts.TimeOptions), nil
+	case "generalizedtime":
+		return NewGeneralizedTimeWithOptions(t, opts.TimeOptions), nil
+	case "rfc5280time":
+		return NewRFC5280TimeWithOptions(t, opts.TimeOptions), nil
+	default:
+		return nil, fmt.Errorf("unsupported default time type: %s", opts.DefaultTimeType)
+	}
+}
+
 // tryCustomMarshalWithoutFieldInfo attempts to use a custom marshaler if the value implements ASN1Marshaler.
 // This version is used when there's no explicit field info (e.g., for slice elements).
 // Returns (result, nil) if successful, (nil, error) if custom marshaler exists but fails,
@@ -446,6 +663,23 @@ func marshalTypedValue(v reflect.Value, info *fieldInfo, opts *MarshalOptions) (
 		return marshalTypedValue(v.Elem(), info, opts)
 	}
 
+	// Deferred values carry their own encoding whatever the declared type
+	if isLazy(v) {
+		obj, _ := asASN1Object(v)
+		return obj.(lazyDecoder).marshalWithOptions(opts)
+	}
+
+	if info.ChoiceSpec != "" {
+		return marshalChoiceSpec(v, info, opts)
+	}
+
+	// Registered CHOICE interfaces tag the alternative they hold
+	if v.Kind() == reflect.Interface {
+		if choice := lookupChoice(v.Type()); choice != nil {
+			return choice.marshal(v, opts)
+		}
+	}
+
 	switch info.Type {
 	case "boolean":
 		if v.Kind() != reflect.Bool {
@@ -463,6 +697,16 @@ func marshalTypedValue(v reflect.Value, info *fieldInfo, opts *MarshalOptions) (
 			return nil, fmt.Errorf("expected integer type for integer, got %v", v.Type())
 		}
 
+	case "enumerated":
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return NewEnumerated(v.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return NewEnumerated(int64(v.Uint())), nil
+		default:
+			return nil, fmt.Errorf("expected integer type for enumerated, got %v", v.Type())
+		}
+
 	case "octetstring":
 		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
 			return NewOctetString(v.Bytes()), nil
@@ -473,39 +717,138 @@ func marshalTypedValue(v reflect.Value, info *fieldInfo, opts *MarshalOptions) (
 		if v.Kind() != reflect.String {
 			return nil, fmt.Errorf("expected string for utf8string, got %v", v.Type())
 		}
-		return NewUTF8String(v.String()), nil
+		return newStringObject(TagUTF8String, v.String())
 
 	case "printablestring":
 		if v.Kind() != reflect.String {
 			return nil, fmt.Errorf("expected string for printablestring, got %v", v.Type())
 		}
-		return NewPrintableString(v.String()), nil
+		return newStringObject(TagPrintableString, v.String())
 
 	case "ia5string":
 		if v.Kind() != reflect.String {
 			return nil, fmt.Errorf("expected string for ia5string, got %v", v.Type())
 		}
-		return NewIA5String(v.String()), nil
+		return newStringObject(TagIA5String, v.String())
+
+	case "numericstring", "visiblestring", "teletexstring", "t61string", "videotexstring",
+		"graphicstring", "generalstring", "bmpstring", "universalstring", "objectdescriptor":
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("expected string for %s, got %v", info.Type, v.Type())
+		}
+		return newStringObject(stringTypeTags[info.Type], v.String())
 
 	case "utctime":
 		if v.Type() == reflect.TypeOf(time.Time{}) {
-			return NewUTCTime(v.Interface().(time.Time)), nil
+			return NewUTCTimeWithOptions(v.Interface().(time.Time), opts.TimeOptions), nil
 		}
 		return nil, fmt.Errorf("expected time.Time for utctime, got %v", v.Type())
 
 	case "generalizedtime":
 		if v.Type() == reflect.TypeOf(time.Time{}) {
-			return NewGeneralizedTime(v.Interface().(time.Time)), nil
+			return NewGeneralizedTimeWithOptions(v.Interface().(time.Time), opts.TimeOptions), nil
 		}
 		return nil, fmt.Errorf("expected time.Time for generalizedtime, got %v", v.Type())
 
+	case "rfc5280time":
+		// UTCTime through 2049, GeneralizedTime from 2050 (RFC 5280 section 4.1.2.5)
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return NewRFC5280TimeWithOptions(v.Interface().(time.Time), opts.TimeOptions), nil
+		}
+		return nil, fmt.Errorf("expected time.Time for rfc5280time, got %v", v.Type())
+
+	case "date":
+		switch value := v.Interface().(type) {
+		case Date:
+			return NewDate(value), nil
+		case time.Time:
+			return NewDate(DateOf(value)), nil
+		}
+		return nil, fmt.Errorf("expected asn1.Date or time.Time for date, got %v", v.Type())
+
+	case "timeofday":
+		switch value := v.Interface().(type) {
+		case TimeOfDay:
+			return NewTimeOfDay(value), nil
+		case time.Time:
+			return NewTimeOfDay(TimeOfDayOf(value)), nil
+		}
+		return nil, fmt.Errorf("expected asn1.TimeOfDay or time.Time for timeofday, got %v", v.Type())
+
+	case "datetime":
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return NewDateTime(v.Interface().(time.Time)), nil
+		}
+		return nil, fmt.Errorf("expected time.Time for datetime, got %v", v.Type())
+
+	case "duration":
+		if v.Type() == reflect.TypeOf(time.Duration(0)) {
+			return NewDuration(time.Duration(v.Int())), nil
+		}
+		return nil, fmt.Errorf("expected time.Duration for duration, got %v", v.Type())
+
+	case "time":
+		if v.Kind() == reflect.String {
+			return NewTime(v.String()), nil
+		}
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return NewTime(v.Interface().(time.Time).Format(time.RFC3339Nano)), nil
+		}
+		return nil, fmt.Errorf("expected string or time.Time for time, got %v", v.Type())
+
+	case "objectidentifier":
+		arcs, err := oidArcs(v)
+		if err != nil {
+			return nil, fmt.Errorf("objectidentifier: %w", err)
+		}
+		return NewObjectIdentifierFromArcs(arcs)
+
+	case "relativeoid":
+		arcs, err := oidArcs(v)
+		if err != nil {
+			return nil, fmt.Errorf("relativeoid: %w", err)
+		}
+		return NewRelativeOIDFromArcs(arcs)
+
+	case "oidiri":
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("expected string for oidiri, got %v", v.Type())
+		}
+		return NewOIDIRI(v.String())
+
+	case "relativeoidiri":
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("expected string for relativeoidiri, got %v", v.Type())
+		}
+		return NewRelativeOIDIRI(v.String())
+
+	case "external", "embeddedpdv", "characterstring":
+		obj, _ := asASN1Object(v)
+		switch obj.(type) {
+		case *ASN1External:
+			if info.Type == "external" {
+				return obj, nil
+			}
+		case *ASN1EmbeddedPDV:
+			if info.Type == "embeddedpdv" {
+				return obj, nil
+			}
+		case *ASN1CharacterString:
+			if info.Type == "characterstring" {
+				return obj, nil
+			}
+		}
+		return nil, fmt.Errorf("expected the asn1 object type for %s, got %v", info.Type, v.Type())
+
 	case "sequence":
 		if v.Kind() == reflect.Struct {
 			return marshalStruct(v, opts)
 		} else if v.Kind() == reflect.Slice {
-			return marshalSlice(v, opts)
+			return marshalSlice(v, info.ElemType, opts)
+		} else if v.Kind() == reflect.Map {
+			return marshalMap(v, info.KeyType, info.ElemType, opts)
 		}
-		return nil, fmt.Errorf("expected struct or slice for sequence, got %v", v.Type())
+		return nil, fmt.Errorf("expected struct, slice or map for sequence, got %v", v.Type())
 
 	case "choice":
 		// Handle CHOICE types - the field should be interface{} or a choice struct
@@ -519,11 +862,78 @@ func marshalTypedValue(v reflect.Value, info *fieldInfo, opts *MarshalOptions) (
 		}
 		return nil, fmt.Errorf("expected interface{} or struct for choice, got %v", v.Type())
 
+	case "extensions":
+		// The unknown alternative of an extensible CHOICE
+		if v.Type() == rawValueType {
+			return marshalValue(v, opts)
+		}
+		return nil, fmt.Errorf("expected RawValue for extensions alternative, got %v", v.Type())
+
+	case "any":
+		// Open types carry an already encoded value or an ASN.1 object
+		if v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, fmt.Errorf("any field is nil")
+			}
+			return marshalValue(v.Elem(), opts)
+		}
+		if v.Type() == rawValueType {
+			return marshalValue(v, opts)
+		}
+		if obj, ok := asASN1Object(v); ok {
+			return obj, nil
+		}
+		return nil, fmt.Errorf("expected asn1.RawValue or ASN1Object for any, got %v", v.Type())
+
 	default:
 		return nil, fmt.Errorf("unsupported ASN.1 type: %s", info.Type)
 	}
 }
 
+var asn1ObjectType = reflect.TypeOf((*ASN1Object)(nil)).Elem()
+
+// asASN1Object returns v as an ASN1Object if it is one, either directly or
+// through its address (for struct values of types like ASN1External)
+func asASN1Object(v reflect.Value) (ASN1Object, bool) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, false
+	}
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		obj, ok := v.Interface().(ASN1Object)
+		return obj, ok
+	}
+	if v.Kind() == reflect.Struct && reflect.PointerTo(v.Type()).Implements(asn1ObjectType) {
+		copied := reflect.New(v.Type())
+		copied.Elem().Set(v)
+		return copied.Interface().(ASN1Object), true
+	}
+	return nil, false
+}
+
+// oidArcs returns the arcs held by an integer slice, a []*big.Int or a dot-separated string
+func oidArcs(v reflect.Value) ([]*big.Int, error) {
+	switch {
+	case v.Kind() == reflect.String:
+		return parseArcs(v.String())
+
+	case v.Type() == reflect.TypeOf([]*big.Int(nil)):
+		return v.Interface().([]*big.Int), nil
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() >= reflect.Int && v.Type().Elem().Kind() <= reflect.Uint64:
+		arcs := make([]*big.Int, v.Len())
+		for i := range arcs {
+			elem := v.Index(i)
+			if elem.Kind() <= reflect.Int64 {
+				arcs[i] = big.NewInt(elem.Int())
+			} else {
+				arcs[i] = new(big.Int).SetUint64(elem.Uint())
+			}
+		}
+		return arcs, nil
+	}
+	return nil, fmt.Errorf("expected integer slice or dot-separated string, got %v", v.Type())
+}
+
 // wrapCustomMarshaledBytes wraps custom marshaled bytes with the appropriate ASN.1 tag
 func wrapCustomMarshaledBytes(rawBytes []byte, info *fieldInfo) (ASN1Object, error) {
 	// Create an ASN.1 object based on the field's type tag
@@ -537,12 +947,15 @@ func wrapCustomMarshaledBytes(rawBytes []byte, info *fieldInfo) (ASN1Object, err
 			return nil, fmt.Errorf("failed to decode custom marshaled integer: %w", err)
 		}
 		return NewIntegerFromBigInt(intVal), nil
-	case "utf8string":
-		return NewUTF8String(string(rawBytes)), nil
-	case "printablestring":
-		return NewPrintableString(string(rawBytes)), nil
-	case "ia5string":
-		return NewIA5String(string(rawBytes)), nil
+	case "enumerated":
+		enumVal, err := DecodeEnumeratedValue(rawBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode custom marshaled enumerated: %w", err)
+		}
+		return NewEnumeratedFromBigInt(enumVal), nil
+	case "utf8string", "printablestring", "ia5string", "numericstring", "visiblestring",
+		"teletexstring", "t61string", "videotexstring", "graphicstring", "generalstring", "bmpstring", "universalstring", "objectdescriptor":
+		return decodeStringObject(stringTypeTags[info.Type], rawBytes)
 	case "sequence":
 		// For sequence, the custom marshaler should return properly encoded sequence content
 		// We need to decode it as a TLV and convert to ASN1Structured
@@ -558,7 +971,11 @@ func wrapCustomMarshaledBytes(rawBytes []byte, info *fieldInfo) (ASN1Object, err
 		return NewBoolean(rawBytes[0] != 0), nil
 	case "bitstring":
 		// For bit string, assume the raw bytes are the bit string content with no unused bits
-		return NewBitString(rawBytes, 0), nil
+		bitString, err := NewBitStringChecked(rawBytes, 0)
+		if err != nil {
+			return nil, err
+		}
+		return bitString, nil
 	default:
 		// For unknown types or generic cases, wrap as octet string
 		return NewOctetString(rawBytes), nil
@@ -590,6 +1007,20 @@ func extractRawBytes(obj ASN1Object) ([]byte, error) {
 		return []byte(o.Value()), nil
 	case *ASN1IA5String:
 		return []byte(o.Value()), nil
+	case *ASN1NumericString, *ASN1VisibleString, *ASN1TeletexString, *ASN1VideotexString,
+		*ASN1GraphicString, *ASN1GeneralString, *ASN1BMPString, *ASN1UniversalString, *ASN1ObjectDescriptor,
+		*ASN1Date, *ASN1TimeOfDay, *ASN1DateTime, *ASN1Duration, *ASN1Time,
+		*ASN1ObjectIdentifier, *ASN1RelativeOID, *ASN1OIDIRI, *ASN1RelativeOIDIRI, *ASN1Enumerated:
+		// These types have their own content encoding, so take it from the TLV
+		encoded, err := o.Encode()
+		if err != nil {
+			return nil, err
+		}
+		val, _, err := DecodeTLV(encoded)
+		if err != nil {
+			return nil, err
+		}
+		return val.Value(), nil
 	case *ASN1Boolean:
 		// ASN.1 BOOLEAN encoding: 0x00 = false, 0xFF (or any non-zero) = true
 		if o.Value() {
@@ -632,6 +1063,33 @@ func unmarshalValue(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error
 		}
 	}
 
+	// Deferred values keep the encoding for decoding on first use
+	if isLazy(v) && v.CanAddr() {
+		return v.Addr().Interface().(lazyDecoder).setEncoding(obj, opts)
+	}
+
+	// Open type targets receive the complete encoding, tag included
+	if v.Type() == rawValueType || v.Type() == asn1ValueType {
+		return unmarshalRaw(obj, v, opts)
+	}
+
+	// ASN.1 object targets receive the decoded object itself
+	if v.Type() == asn1ObjectType {
+		v.Set(reflect.ValueOf(obj))
+		return nil
+	}
+	if objValue := reflect.ValueOf(obj); objValue.Type() == v.Type() {
+		v.Set(objValue)
+		return nil
+	} else if objValue.Kind() == reflect.Ptr && objValue.Elem().Type() == v.Type() {
+		v.Set(objValue.Elem())
+		return nil
+	}
+	if v.Type() == reflect.TypeOf(ASN1Choice{}) {
+		v.Set(reflect.ValueOf(*NewChoice(obj)))
+		return nil
+	}
+
 	// Handle pointer types
 	if v.Kind() == reflect.Ptr {
 		if v.IsNil() {
@@ -646,6 +1104,8 @@ func unmarshalValue(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error
 		return unmarshalStruct(obj, v, opts)
 	case reflect.Slice:
 		return unmarshalSlice(obj, v, opts)
+	case reflect.Map:
+		return unmarshalMap(obj, v, opts)
 	case reflect.String:
 		return unmarshalString(obj, v)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -655,6 +1115,9 @@ func unmarshalValue(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error
 	case reflect.Bool:
 		return unmarshalBool(obj, v)
 	case reflect.Interface:
+		if choice := lookupChoice(v.Type()); choice != nil {
+			return choice.unmarshal(obj, v, opts)
+		}
 		// Handle interface{} for choice types
 		return unmarshalInterface(obj, v)
 	default:
@@ -668,15 +1131,33 @@ func unmarshalStruct(obj ASN1Object, v reflect.Value, opts *MarshalOptions) erro
 		// Special handling for time.Time
 		return unmarshalTime(obj, v)
 	}
+	switch v.Type() {
+	case reflect.TypeOf(Date{}):
+		date, ok := obj.(*ASN1Date)
+		if !ok {
+			return typeMismatch(NewUniversalTag(TagDate, false), obj, fmt.Sprintf("expected ASN1Date, got %T", obj))
+		}
+		v.Set(reflect.ValueOf(date.Date()))
+		return nil
+	case reflect.TypeOf(TimeOfDay{}):
+		timeOfDay, ok := obj.(*ASN1TimeOfDay)
+		if !ok {
+			return typeMismatch(NewUniversalTag(TagTimeOfDay, false), obj, fmt.Sprintf("expected ASN1TimeOfDay, got %T", obj))
+		}
+		v.Set(reflect.ValueOf(timeOfDay.TimeOfDay()))
+		return nil
+	}
 
 	structured, ok := obj.(*ASN1Structured)
 	if !ok {
-		return fmt.Errorf("expected ASN1Structured for struct, got %T", obj)
+		return typeMismatch(NewUniversalTag(TagSequence, true), obj, fmt.Sprintf("expected ASN1Structured for struct, got %T", obj))
 	}
 
 	elements := structured.Elements()
 	t := v.Type()
 	elementIndex := 0
+	var extensions reflect.Value
+	var extensionsName string
 
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
@@ -699,21 +1180,37 @@ func unmarshalStruct(obj ASN1Object, v reflect.Value, opts *MarshalOptions) erro
 		if tag != "" {
 			info, err = parseASN1Tag(tag)
 			if err != nil {
-				return fmt.Errorf("field %s: %w", fieldType.Name, err)
+				return atPath(err, fieldType.Name, obj, opts)
 			}
 		} else {
 			info = &fieldInfo{Type: "auto"}
 		}
 
+		if isRawContentField(fieldType, info) {
+			if err := setRawContent(obj, field); err != nil {
+				return atPath(err, fieldType.Name, obj, opts)
+			}
+			continue
+		}
+		if isExtensionsField(info) {
+			// Filled in with the components left over at the end
+			extensions, extensionsName = field, fieldType.Name
+			continue
+		}
+
 		// Check if we have more elements
 		if elementIndex >= len(elements) {
 			if info.Optional {
+				if err := setAbsentField(field, info); err != nil {
+					return atPath(err, fieldType.Name, obj, opts)
+				}
 				continue // Skip optional fields if no more elements
 			}
-			return fmt.Errorf("not enough elements for required field %s", fieldType.Name)
+			return atPath(newStructuralError("not enough elements for required field %s", fieldType.Name), fieldType.Name, obj, opts)
 		}
 
 		element := elements[elementIndex]
+		original := element
 
 		// Handle context-specific tags
 		if info.HasTag && opts.UseContextTags {
@@ -722,37 +1219,56 @@ func unmarshalStruct(obj ASN1Object, v reflect.Value, opts *MarshalOptions) erro
 				// Tag matches, consume the element
 				elementIndex++
 
-				if info.Explicit {
-					// EXPLICIT tagging: unwrap to get the inner element
-					if wrapped, ok := element.(*ASN1Structured); ok {
-						wrappedElements := wrapped.Elements()
-						if len(wrappedElements) == 1 {
-							element = wrappedElements[0]
-						}
-					}
-				} else {
-					// IMPLICIT tagging: restore the original tag
-					element = restoreTag(element, info.Type)
+				explicit, err := isExplicitTag(field.Type(), info, opts)
+				if err != nil {
+					return atPath(err, fieldType.Name, obj, opts)
 				}
+				element = removeContextTag(element, field.Type(), info, explicit)
 			} else {
 				// Tag doesn't match
 				if info.Optional {
 					// Optional field not present, skip without consuming element
+					if err := setAbsentField(field, info); err != nil {
+						return atPath(err, fieldType.Name, obj, opts)
+					}
 					continue
 				}
 				// Required field with wrong tag - this is an error
-				return fmt.Errorf("field %s: expected tag [CONTEXT %d], got %s", 
-					fieldType.Name, info.Tag, element.Tag().TagString())
+				return atPath(newTagMismatch(NewContextSpecificTag(info.Tag, false), element), fieldType.Name, element, opts)
+			}
+		} else if info.Optional && !fieldAcceptsElement(fieldType.Type, info, element, opts) {
+			// Untagged optional field not present, skip without consuming element
+			if err := setAbsentField(field, info); err != nil {
+				return atPath(err, fieldType.Name, obj, opts)
 			}
+			continue
 		} else {
 			// No specific tag expected, consume the element
 			elementIndex++
 		}
 
 		// Unmarshal the element
-		if err := unmarshalValue(element, field, opts); err != nil {
-			return fmt.Errorf("field %s: %w", fieldType.Name, err)
+		if info.ChoiceSpec != "" {
+			err = unmarshalChoiceSpec(element, field, info, opts)
+		} else if info.Type == "choice" && isChoiceStruct(field.Type()) {
+			err = unmarshalChoiceStruct(element, field, opts)
+		} else {
+			err = unmarshalValue(element, field, opts)
+		}
+		if err != nil {
+			return atPath(err, fieldType.Name, original, opts)
+		}
+	}
+
+	// An extensible SEQUENCE keeps components added by newer versions
+	if extensions.IsValid() {
+		if err := setExtensions(extensions, elements[elementIndex:], opts); err != nil {
+			return atPath(err, extensionsName, obj, opts)
 		}
+	} else if opts.RejectUnknownElements && elementIndex < len(elements) {
+		err := newStructuralError("%d unexpected elements after the last field of %v, the first with tag %s",
+			len(elements)-elementIndex, t, elements[elementIndex].Tag().TagString())
+		return atPath(err, "", elements[elementIndex], opts)
 	}
 
 	return nil
@@ -766,12 +1282,35 @@ func unmarshalSlice(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error
 			v.SetBytes(octets.Value())
 			return nil
 		}
-		return fmt.Errorf("expected ASN1OctetString for []byte, got %T", obj)
+		return typeMismatch(NewUniversalTag(TagOctetString, false), obj, fmt.Sprintf("expected ASN1OctetString for []byte, got %T", obj))
+	}
+
+	// Object identifier arcs decode into integer or []*big.Int slices
+	var arcs []*big.Int
+	switch oid := obj.(type) {
+	case *ASN1ObjectIdentifier:
+		arcs = oid.Arcs()
+	case *ASN1RelativeOID:
+		arcs = oid.Arcs()
+	}
+	if arcs != nil {
+		if v.Type() == reflect.TypeOf([]*big.Int(nil)) {
+			v.Set(reflect.ValueOf(arcs))
+			return nil
+		}
+		slice := reflect.MakeSlice(v.Type(), len(arcs), len(arcs))
+		for i, arc := range arcs {
+			if err := unmarshalValue(NewIntegerFromBigInt(arc), slice.Index(i), opts); err != nil {
+				return atPath(err, fmt.Sprintf("[%d]", i), obj, opts)
+			}
+		}
+		v.Set(slice)
+		return nil
 	}
 
 	structured, ok := obj.(*ASN1Structured)
 	if !ok {
-		return fmt.Errorf("expected ASN1Structured for slice, got %T", obj)
+		return typeMismatch(NewUniversalTag(TagSequence, true), obj, fmt.Sprintf("expected ASN1Structured for slice, got %T", obj))
 	}
 
 	elements := structured.Elements()
@@ -779,7 +1318,7 @@ func unmarshalSlice(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error
 
 	for i, element := range elements {
 		if err := unmarshalValue(element, slice.Index(i), opts); err != nil {
-			return fmt.Errorf("slice element %d: %w", i, err)
+			return atPath(err, fmt.Sprintf("[%d]", i), element, opts)
 		}
 	}
 
@@ -787,6 +1326,39 @@ func unmarshalSlice(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error
 	return nil
 }
 
+// unmarshalMap converts a SEQUENCE OF SEQUENCE { key, value } to a Go map
+func unmarshalMap(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error {
+	structured, ok := obj.(*ASN1Structured)
+	if !ok {
+		return typeMismatch(NewUniversalTag(TagSequence, true), obj, fmt.Sprintf("expected ASN1Structured for map, got %T", obj))
+	}
+
+	elements := structured.Elements()
+	m := reflect.MakeMapWithSize(v.Type(), len(elements))
+
+	for i, element := range elements {
+		entry, ok := element.(*ASN1Structured)
+		if !ok || len(entry.Elements()) != 2 {
+			err := newStructuralError("expected SEQUENCE of key and value, got %s", element.TaggedString())
+			return atPath(err, fmt.Sprintf("[%d]", i), element, opts)
+		}
+		pair := entry.Elements()
+
+		key := reflect.New(v.Type().Key()).Elem()
+		if err := unmarshalValue(pair[0], key, opts); err != nil {
+			return atPath(atPath(err, "key", pair[0], opts), fmt.Sprintf("[%d]", i), element, opts)
+		}
+		value := reflect.New(v.Type().Elem()).Elem()
+		if err := unmarshalValue(pair[1], value, opts); err != nil {
+			return atPath(atPath(err, "value", pair[1], opts), fmt.Sprintf("[%d]", i), element, opts)
+		}
+		m.SetMapIndex(key, value)
+	}
+
+	v.Set(m)
+	return nil
+}
+
 // marshalChoiceStruct handles structs marked as CHOICE types
 func marshalChoiceStruct(v reflect.Value, opts *MarshalOptions) (ASN1Object, error) {
 	t := v.Type()
@@ -856,36 +1428,155 @@ func marshalChoiceStruct(v reflect.Value, opts *MarshalOptions) (ASN1Object, err
 
 	// Apply context-specific tag if specified
 	if chosenInfo.HasTag && opts.UseContextTags {
-		contextTag := NewContextSpecificTag(chosenInfo.Tag, isConstructedType(obj))
-		wrapped := NewStructured(contextTag)
-		wrapped.Add(obj)
-		obj = wrapped
+		explicit, err := isExplicitTag(chosenField.Type(), chosenInfo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", chosenFieldName, err)
+		}
+		obj = applyContextTag(obj, chosenInfo.Tag, explicit)
 	}
 
 	return obj, nil
 }
 
+// isChoiceStruct reports whether t (or the type it points to) is a union
+// struct whose exported fields are all pointers, one per alternative
+func isChoiceStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	alternatives := 0
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("asn1") == "-" {
+			continue
+		}
+		if field.Type.Kind() != reflect.Ptr {
+			return false
+		}
+		alternatives++
+	}
+	return alternatives > 0
+}
+
+// unmarshalChoiceStruct decodes a CHOICE into a union struct. The alternative
+// is chosen by the element's tag, and only its pointer field is set.
+func unmarshalChoiceStruct(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return unmarshalChoiceStruct(obj, v.Elem(), opts)
+	}
+
+	t := v.Type()
+	unknown := -1
+	for i := 0; i < v.NumField(); i++ {
+		fieldType := t.Field(i)
+
+		// Skip unexported fields
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		tag := fieldType.Tag.Get("asn1")
+		if tag == "-" {
+			continue
+		}
+
+		info := &fieldInfo{Type: "auto"}
+		if tag != "" {
+			var err error
+			info, err = parseASN1Tag(tag)
+			if err != nil {
+				return atPath(err, fieldType.Name, obj, opts)
+			}
+		}
+
+		if isExtensionsField(info) {
+			// Catch-all for alternatives added by newer versions
+			unknown = i
+			continue
+		}
+		if !fieldAcceptsElement(fieldType.Type, info, obj, opts) {
+			continue
+		}
+
+		element := obj
+		if info.HasTag && opts.UseContextTags {
+			explicit, err := isExplicitTag(fieldType.Type, info, opts)
+			if err != nil {
+				return atPath(err, fieldType.Name, obj, opts)
+			}
+			element = removeContextTag(obj, fieldType.Type, info, explicit)
+		}
+
+		// Only the chosen alternative is set
+		v.Set(reflect.Zero(t))
+		alternative := reflect.New(fieldType.Type.Elem())
+		var err error
+		if info.Type == "choice" && isChoiceStruct(fieldType.Type) {
+			err = unmarshalChoiceStruct(element, alternative.Elem(), opts)
+		} else {
+			err = unmarshalValue(element, alternative.Elem(), opts)
+		}
+		if err != nil {
+			return atPath(err, fieldType.Name, obj, opts)
+		}
+		v.Field(i).Set(alternative)
+		return nil
+	}
+
+	if unknown >= 0 {
+		v.Set(reflect.Zero(t))
+		if err := setUnknownAlternative(obj, v.Field(unknown), opts); err != nil {
+			return atPath(err, t.Field(unknown).Name, obj, opts)
+		}
+		return nil
+	}
+
+	return newStructuralError("no alternative of %v matches tag %s", t, obj.Tag().TagString())
+}
+
 // Helper functions for unmarshaling basic types
 func unmarshalString(obj ASN1Object, v reflect.Value) error {
-	switch s := obj.(type) {
-	case *ASN1UTF8String:
-		v.SetString(s.Value())
-	case *ASN1PrintableString:
-		v.SetString(s.Value())
-	case *ASN1IA5String:
-		v.SetString(s.Value())
-	default:
+	switch o := obj.(type) {
+	case *ASN1Time:
+		v.SetString(o.Value())
+		return nil
+	case *ASN1ObjectIdentifier:
+		v.SetString(o.DotNotation())
+		return nil
+	case *ASN1RelativeOID:
+		v.SetString(o.DotNotation())
+		return nil
+	case *ASN1OIDIRI:
+		v.SetString(o.Value())
+		return nil
+	case *ASN1RelativeOIDIRI:
+		v.SetString(o.Value())
+		return nil
+	}
+	s, ok := stringObjectValue(obj)
+	if !ok {
 		return fmt.Errorf("expected string type, got %T", obj)
 	}
+	v.SetString(s)
 	return nil
 }
 
 func unmarshalInt(obj ASN1Object, v reflect.Value) error {
-	integer, ok := obj.(*ASN1Integer)
-	if !ok {
-		return fmt.Errorf("expected ASN1Integer, got %T", obj)
+	if duration, ok := obj.(*ASN1Duration); ok && v.Type() == reflect.TypeOf(time.Duration(0)) {
+		v.SetInt(int64(duration.Duration()))
+		return nil
+	}
+	val, err := integerValue(obj)
+	if err != nil {
+		return err
 	}
-	val := integer.Value()
 	if !val.IsInt64() {
 		return fmt.Errorf("integer value too large for int64")
 	}
@@ -894,11 +1585,10 @@ func unmarshalInt(obj ASN1Object, v reflect.Value) error {
 }
 
 func unmarshalUint(obj ASN1Object, v reflect.Value) error {
-	integer, ok := obj.(*ASN1Integer)
-	if !ok {
-		return fmt.Errorf("expected ASN1Integer, got %T", obj)
+	val, err := integerValue(obj)
+	if err != nil {
+		return err
 	}
-	val := integer.Value()
 	if val.Sign() < 0 {
 		return fmt.Errorf("cannot convert negative integer to unsigned")
 	}
@@ -909,10 +1599,21 @@ func unmarshalUint(obj ASN1Object, v reflect.Value) error {
 	return nil
 }
 
+// integerValue returns the value of an INTEGER or ENUMERATED
+func integerValue(obj ASN1Object) (*big.Int, error) {
+	switch o := obj.(type) {
+	case *ASN1Integer:
+		return o.Value(), nil
+	case *ASN1Enumerated:
+		return o.Value(), nil
+	}
+	return nil, typeMismatch(NewUniversalTag(TagInteger, false), obj, fmt.Sprintf("expected ASN1Integer, got %T", obj))
+}
+
 func unmarshalBool(obj ASN1Object, v reflect.Value) error {
 	boolean, ok := obj.(*ASN1Boolean)
 	if !ok {
-		return fmt.Errorf("expected ASN1Boolean, got %T", obj)
+		return typeMismatch(NewUniversalTag(TagBoolean, false), obj, fmt.Sprintf("expected ASN1Boolean, got %T", obj))
 	}
 	v.SetBool(boolean.Value())
 	return nil
@@ -924,6 +1625,16 @@ func unmarshalTime(obj ASN1Object, v reflect.Value) error {
 		v.Set(reflect.ValueOf(t.Time()))
 	case *ASN1GeneralizedTime:
 		v.Set(reflect.ValueOf(t.Time()))
+	case *ASN1DateTime:
+		v.Set(reflect.ValueOf(t.Time()))
+	case *ASN1Date:
+		v.Set(reflect.ValueOf(t.Date().Time(time.Local)))
+	case *ASN1Time:
+		parsed, err := t.Time()
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(parsed))
 	default:
 		return fmt.Errorf("expected time type, got %T", obj)
 	}
@@ -949,12 +1660,26 @@ func unmarshalInterface(obj ASN1Object, v reflect.Value) error {
 		v.Set(reflect.ValueOf(o.Value()))
 	case *ASN1IA5String:
 		v.Set(reflect.ValueOf(o.Value()))
+	case *ASN1NumericString, *ASN1VisibleString, *ASN1TeletexString, *ASN1VideotexString,
+		*ASN1GraphicString, *ASN1GeneralString, *ASN1BMPString, *ASN1UniversalString, *ASN1ObjectDescriptor:
+		str, _ := stringObjectValue(o)
+		v.Set(reflect.ValueOf(str))
 	case *ASN1OctetString:
 		v.Set(reflect.ValueOf(o.Value()))
 	case *ASN1UTCTime:
 		v.Set(reflect.ValueOf(o.Time()))
 	case *ASN1GeneralizedTime:
 		v.Set(reflect.ValueOf(o.Time()))
+	case *ASN1DateTime:
+		v.Set(reflect.ValueOf(o.Time()))
+	case *ASN1Date:
+		v.Set(reflect.ValueOf(o.Date()))
+	case *ASN1TimeOfDay:
+		v.Set(reflect.ValueOf(o.TimeOfDay()))
+	case *ASN1Duration:
+		v.Set(reflect.ValueOf(o.Duration()))
+	case *ASN1Time:
+		v.Set(reflect.ValueOf(o.Value()))
 	default:
 		return fmt.Errorf("cannot unmarshal %T to interface{}", obj)
 	}
@@ -974,6 +1699,20 @@ func isConstructedType(obj ASN1Object) bool {
 // replaceTag creates a new ASN.1 object with a context-specific tag replacing the original tag
 // This implements IMPLICIT tagging
 func replaceTag(obj ASN1Object, tagNum int) ASN1Object {
+	return replaceTagClass(obj, 2, tagNum) // Context-specific
+}
+
+// replaceTagClass is replaceTag for tags of any class
+func replaceTagClass(obj ASN1Object, class, tagNum int) ASN1Object {
+	// Structured objects keep their elements rather than being decoded again
+	if structured, ok := obj.(*ASN1Structured); ok {
+		retagged := NewStructured(NewTag(class, structured.Tag().Constructed, tagNum))
+		for _, element := range structured.Elements() {
+			retagged.Add(element)
+		}
+		return retagged
+	}
+
 	// Get the raw encoded value
 	encoded, err := obj.Encode()
 	if err != nil {
@@ -988,9 +1727,9 @@ func replaceTag(obj ASN1Object, tagNum int) ASN1Object {
 		return obj
 	}
 
-	// Create new tag with context-specific class, preserving constructed bit
+	// Create new tag with the given class, preserving constructed bit
 	newTag := Tag{
-		Class:       2, // Context-specific
+		Class:       class,
 		Constructed: origValue.Tag().Constructed,
 		Number:      tagNum,
 	}
@@ -1022,22 +1761,9 @@ func replaceTag(obj ASN1Object, tagNum int) ASN1Object {
 	return newValue
 }
 
-// restoreTag restores the original universal tag from an implicitly tagged object
-// This is used during unmarshaling to convert context-specific tags back to universal tags
-func restoreTag(obj ASN1Object, asn1Type string) ASN1Object {
-	// Get the raw encoded value
-	encoded, err := obj.Encode()
-	if err != nil {
-		return obj
-	}
-
-	// Decode to get the current TLV structure
-	currentValue, _, err := DecodeTLV(encoded)
-	if err != nil {
-		return obj
-	}
-
-	// Map ASN.1 type name to universal tag number
+// universalTypeTag returns the universal tag of an ASN.1 type name used in
+// struct tags. CHOICE and other types without a single tag report false.
+func universalTypeTag(asn1Type string) (Tag, bool) {
 	var tagNum int
 	var constructed bool
 
@@ -1046,6 +1772,8 @@ func restoreTag(obj ASN1Object, asn1Type string) ASN1Object {
 		tagNum = TagBoolean
 	case "integer":
 		tagNum = TagInteger
+	case "enumerated":
+		tagNum = TagEnumerated
 	case "octetstring":
 		tagNum = TagOctetString
 	case "utf8string":
@@ -1054,52 +1782,100 @@ func restoreTag(obj ASN1Object, asn1Type string) ASN1Object {
 		tagNum = TagPrintableString
 	case "ia5string":
 		tagNum = TagIA5String
+	case "numericstring", "visiblestring", "teletexstring", "t61string", "videotexstring",
+		"graphicstring", "generalstring", "bmpstring", "universalstring", "objectdescriptor":
+		tagNum = stringTypeTags[strings.ToLower(asn1Type)]
 	case "utctime":
 		tagNum = TagUTCTime
 	case "generalizedtime":
 		tagNum = TagGeneralizedTime
+	case "date":
+		tagNum = TagDate
+	case "timeofday":
+		tagNum = TagTimeOfDay
+	case "datetime":
+		tagNum = TagDateTime
+	case "duration":
+		tagNum = TagDuration
+	case "time":
+		tagNum = TagTime
+	case "objectidentifier":
+		tagNum = TagOID
+	case "relativeoid":
+		tagNum = TagRelativeOID
+	case "oidiri":
+		tagNum = TagOIDIRI
+	case "relativeoidiri":
+		tagNum = TagRelativeOIDIRI
+	case "external":
+		tagNum = TagExternal
+		constructed = true
+	case "embeddedpdv":
+		tagNum = TagEmbeddedPDV
+		constructed = true
+	case "characterstring":
+		tagNum = TagCharacterString
+		constructed = true
 	case "sequence":
 		tagNum = TagSequence
 		constructed = true
 	case "set":
 		tagNum = TagSet
 		constructed = true
-	case "choice":
-		// For CHOICE types, we need to restore to a SEQUENCE tag
-		// since the choice struct is represented as a SEQUENCE with one alternative
-		tagNum = TagSequence
-		constructed = true
 	default:
+		return Tag{}, false
+	}
+
+	return NewUniversalTag(tagNum, constructed), true
+}
+
+// restoreTag restores the original universal tag from an implicitly tagged object
+// This is used during unmarshaling to convert context-specific tags back to universal tags
+func restoreTag(obj ASN1Object, asn1Type string) ASN1Object {
+	// Map ASN.1 type name to universal tag number
+	newTag, ok := universalTypeTag(asn1Type)
+	if !ok {
 		// Unknown type, return as-is
 		return obj
 	}
+	return restoreUniversalTag(obj, newTag)
+}
 
-	// Create new tag with universal class
-	newTag := Tag{
-		Class:       0, // Universal
-		Constructed: constructed,
-		Number:      tagNum,
+// restoreUniversalTag replaces the tag of an implicitly tagged object with newTag
+func restoreUniversalTag(obj ASN1Object, newTag Tag) ASN1Object {
+	// Get the raw encoded value
+	encoded, err := originalEncoding(obj)
+	if err != nil {
+		return obj
 	}
 
+	// Decode to get the current TLV structure
+	currentValue, _, err := decodeTLV(encoded)
+	if err != nil {
+		return obj
+	}
+
+	constructed := newTag.Constructed
+
 	// Create new ASN1Value with the restored tag and same content
 	newValue := NewASN1Value(newTag, currentValue.Value())
 
 	// Convert to appropriate high-level object
 	if constructed {
-		structured := NewStructured(newTag)
-		content := newValue.Value()
-		offset := 0
+		current, ok := obj.(*ASN1Structured)
+		if !ok {
+			// A primitive encoding cannot hold a constructed type
+			return obj
+		}
 
-		for offset < len(content) {
-			elementValue, consumed, err := DecodeTLV(content[offset:])
-			if err != nil {
-				break
-			}
-			element := convertToHighLevelObject(elementValue)
+		// Reuse the decoded elements rather than decoding the content again
+		structured := NewStructured(newTag)
+		for _, element := range current.Elements() {
 			structured.Add(element)
-			offset += consumed
 		}
-		return structured
+		// The verbatim bytes are those on the wire, with the context tag
+		structured.raw = decodedBytes(obj)
+		return convertUniversalStructured(newValue, structured)
 	}
 
 	return convertPrimitiveValue(newValue)
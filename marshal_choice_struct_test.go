@@ -0,0 +1,122 @@
+package asn1
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type messageChoice struct {
+	BoolValue   *bool      `asn1:"boolean,tag:0"`
+	IntValue    *int64     `asn1:"integer,tag:1"`
+	StringValue *string    `asn1:"utf8string,tag:2"`
+	TimeValue   *time.Time `asn1:"utctime,tag:4"`
+}
+
+type choiceDocument struct {
+	ID       int64         `asn1:"integer"`
+	Metadata messageChoice `asn1:"choice"`
+	Title    string        `asn1:"utf8string"`
+}
+
+func TestUnmarshalTaggedChoiceStruct(t *testing.T) {
+	flag := true
+	count := int64(42)
+	text := "important"
+	when := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, choice := range []messageChoice{
+		{BoolValue: &flag},
+		{IntValue: &count},
+		{StringValue: &text},
+		{TimeValue: &when},
+	} {
+		encoded, err := Marshal(choiceDocument{ID: 1, Metadata: choice, Title: "doc"})
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+
+		// Start from a different alternative to check it is cleared
+		decoded := choiceDocument{Metadata: messageChoice{IntValue: new(int64)}}
+		if choice.IntValue != nil {
+			decoded.Metadata = messageChoice{BoolValue: new(bool)}
+		}
+		if err := Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+
+		got := decoded.Metadata
+		switch {
+		case choice.BoolValue != nil:
+			if got.BoolValue == nil || !*got.BoolValue || got.IntValue != nil {
+				t.Errorf("bool alternative = %+v", got)
+			}
+		case choice.IntValue != nil:
+			if got.IntValue == nil || *got.IntValue != 42 || got.BoolValue != nil {
+				t.Errorf("int alternative = %+v", got)
+			}
+		case choice.StringValue != nil:
+			if got.StringValue == nil || *got.StringValue != text || got.IntValue != nil {
+				t.Errorf("string alternative = %+v", got)
+			}
+		case choice.TimeValue != nil:
+			if got.TimeValue == nil || !got.TimeValue.Equal(when) {
+				t.Errorf("time alternative = %+v", got)
+			}
+		}
+		if decoded.ID != 1 || decoded.Title != "doc" {
+			t.Errorf("Unmarshal() = %+v", decoded)
+		}
+	}
+}
+
+type partyName struct {
+	Common *string    `asn1:"printablestring"`
+	Number *int64     `asn1:"integer"`
+	Alias  *aliasName `asn1:"choice"`
+}
+
+type aliasName struct {
+	Short *string `asn1:"ia5string"`
+	Flag  *bool   `asn1:"boolean"`
+}
+
+func TestUnmarshalUntaggedChoiceStruct(t *testing.T) {
+	type party struct {
+		Name  partyName `asn1:"choice"`
+		Extra *int64    `asn1:"integer,optional"`
+	}
+
+	short := "bob"
+	encoded, err := Marshal(party{Name: partyName{Alias: &aliasName{Short: &short}}})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded party
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Name.Alias == nil || decoded.Name.Alias.Short == nil || *decoded.Name.Alias.Short != "bob" {
+		t.Errorf("nested alternative = %+v", decoded.Name)
+	}
+
+	number := int64(7)
+	encoded, err = Marshal(party{Name: partyName{Number: &number}})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	decoded = party{}
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Name.Number == nil || *decoded.Name.Number != 7 || decoded.Name.Common != nil {
+		t.Errorf("number alternative = %+v", decoded.Name)
+	}
+
+	// An OCTET STRING matches no alternative
+	data := []byte{0x30, 0x03, 0x04, 0x01, 0x00}
+	err = Unmarshal(data, &decoded)
+	if err == nil || !strings.Contains(err.Error(), "no alternative") {
+		t.Errorf("Unmarshal() error = %v, want no alternative error", err)
+	}
+}
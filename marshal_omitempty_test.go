@@ -0,0 +1,117 @@
+package asn1
+
+import (
+	"bytes"
+	"testing"
+)
+
+type omitemptyPoint struct {
+	X int64 `asn1:"integer"`
+	Y int64 `asn1:"integer"`
+}
+
+type omitemptyRecord struct {
+	ID     int64             `asn1:"integer"`
+	Name   string            `asn1:"utf8string,omitempty"`
+	Tags   []string          `asn1:"sequence,omitempty"`
+	Attrs  map[string]string `asn1:"sequence,omitempty"`
+	Count  int64             `asn1:"integer,omitempty"`
+	Origin omitemptyPoint    `asn1:"sequence,omitempty"`
+	Note   *string           `asn1:"utf8string,omitempty"`
+}
+
+func TestOmitemptySkipsEmptyValues(t *testing.T) {
+	encoded, err := Marshal(omitemptyRecord{ID: 1})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x03, 0x02, 0x01, 0x01}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+
+	full := omitemptyRecord{ID: 1, Name: "a", Tags: []string{"t"}, Count: 2, Origin: omitemptyPoint{X: 1}}
+	encoded, err = Marshal(full)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want = []byte{
+		0x30, 0x16,
+		0x02, 0x01, 0x01,
+		0x0C, 0x01, 'a',
+		0x30, 0x03, 0x0C, 0x01, 't',
+		0x02, 0x01, 0x02,
+		0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x00,
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+}
+
+type optionalMiddle struct {
+	ID    int64    `asn1:"integer"`
+	Name  string   `asn1:"utf8string,optional"`
+	Tags  []string `asn1:"sequence,optional"`
+	Flag  bool     `asn1:"boolean,optional"`
+	Count int64    `asn1:"integer"`
+}
+
+func TestOptionalNonPointerFields(t *testing.T) {
+	encoded, err := Marshal(optionalMiddle{ID: 1, Count: 2})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x02, 0x01, 0x02}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+
+	// Absent optionals in the middle are skipped, and stale values cleared
+	decoded := optionalMiddle{Name: "stale", Tags: []string{"stale"}, Flag: true}
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.ID != 1 || decoded.Count != 2 || decoded.Name != "" || decoded.Tags != nil || decoded.Flag {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+
+	// Only some optionals present
+	original := optionalMiddle{ID: 1, Tags: []string{"x"}, Count: 2}
+	encoded, err = Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var partial optionalMiddle
+	if err := Unmarshal(encoded, &partial); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if partial.Name != "" || len(partial.Tags) != 1 || partial.Tags[0] != "x" || partial.Count != 2 {
+		t.Errorf("Unmarshal() = %+v", partial)
+	}
+}
+
+type optionalZero struct {
+	Retries int64  `asn1:"integer,optional,tag:0"`
+	Limit   *int64 `asn1:"integer,optional,tag:1"`
+}
+
+func TestOptionalZeroValues(t *testing.T) {
+	// A zero non-pointer optional is absent; a pointer to zero is encoded
+	zero := int64(0)
+	encoded, err := Marshal(optionalZero{Retries: 0, Limit: &zero})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x03, 0x81, 0x01, 0x00}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+
+	var decoded optionalZero
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Retries != 0 || decoded.Limit == nil || *decoded.Limit != 0 {
+		t.Errorf("Unmarshal() = %+v", decoded)
+	}
+}
@@ -0,0 +1,184 @@
+package asn1
+
+import (
+	"testing"
+	"time"
+)
+
+type UntaggedTimes struct {
+	Created time.Time
+	History []time.Time `asn1:"sequence"`
+}
+
+func TestUntaggedTimeDefaultType(t *testing.T) {
+	original := &UntaggedTimes{
+		Created: time.Date(2075, 3, 1, 8, 0, 0, 0, time.UTC),
+		History: []time.Time{
+			time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC),
+			time.Date(2150, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	// The default RFC 5280 policy keeps years outside 1950-2049 intact
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded UntaggedTimes
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !decoded.Created.Equal(original.Created) {
+		t.Errorf("Created = %v, want %v", decoded.Created, original.Created)
+	}
+	if len(decoded.History) != 2 || !decoded.History[0].Equal(original.History[0]) || !decoded.History[1].Equal(original.History[1]) {
+		t.Errorf("History = %v, want %v", decoded.History, original.History)
+	}
+
+	// Forcing UTCTime reports the out-of-range year instead of wrapping it
+	opts := DefaultMarshalOptions()
+	opts.DefaultTimeType = "utctime"
+	if _, err := MarshalWithOptions(original, opts); err == nil {
+		t.Error("expected error for year 2075 encoded as UTCTime")
+	}
+
+	opts.DefaultTimeType = "generalizedtime"
+	encoded, err = MarshalWithOptions(original, opts)
+	if err != nil {
+		t.Fatalf("MarshalWithOptions failed: %v", err)
+	}
+	// SEQUENCE { GeneralizedTime, ... }
+	if encoded[2] != TagGeneralizedTime {
+		t.Errorf("expected GeneralizedTime for Created, got tag %d", encoded[2])
+	}
+
+	opts.DefaultTimeType = "bogus"
+	if _, err := MarshalWithOptions(original, opts); err == nil {
+		t.Error("expected error for unknown default time type")
+	}
+}
+
+type RFC5280Times struct {
+	Untagged time.Time
+	Tagged   time.Time `asn1:"rfc5280time"`
+}
+
+func TestRFC5280TimeHonoursTimeOptions(t *testing.T) {
+	zone := time.FixedZone("", 2*60*60)
+	original := RFC5280Times{
+		Untagged: time.Date(2030, 6, 1, 12, 0, 0, 0, zone),
+		Tagged:   time.Date(2060, 6, 1, 12, 0, 0, 500000000, zone),
+	}
+
+	opts := DefaultMarshalOptions()
+	opts.TimeOptions = TimeOptions{Precision: PrecisionAuto, PreserveOffset: true}
+	encoded, err := MarshalWithOptions(original, opts)
+	if err != nil {
+		t.Fatalf("MarshalWithOptions failed: %v", err)
+	}
+	// SEQUENCE { UTCTime "300601120000+0200", GeneralizedTime "20600601120000.5+0200" }
+	want := "\x17\x11300601120000+0200\x18\x1520600601120000.5+0200"
+	if got := string(encoded[2:]); got != want {
+		t.Errorf("encoded %q, want %q", got, want)
+	}
+
+	var decoded RFC5280Times
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !decoded.Untagged.Equal(original.Untagged) || !decoded.Tagged.Equal(original.Tagged) {
+		t.Errorf("decoded %+v, want %+v", decoded, original)
+	}
+}
+
+type TimeHints struct {
+	Stamps []time.Time          `asn1:"sequence,elem:generalizedtime"`
+	Names  []string             `asn1:"sequence,elem:ia5string"`
+	Events map[string]time.Time `asn1:"sequence,key:printablestring,elem:generalizedtime"`
+	Counts map[int64]bool       `asn1:"sequence"`
+}
+
+func TestElementTypeHints(t *testing.T) {
+	original := &TimeHints{
+		Stamps: []time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		Names:  []string{"alice@example.com"},
+		Events: map[string]time.Time{
+			"start": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			"end":   time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		Counts: map[int64]bool{1: true, 2: false},
+	}
+
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	obj, err := Decode(encoded)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	seq := convertToHighLevelObject(obj.(*ASN1Value)).(*ASN1Structured)
+	fields := seq.Elements()
+
+	if _, ok := fields[0].(*ASN1Structured).Elements()[0].(*ASN1GeneralizedTime); !ok {
+		t.Errorf("expected GeneralizedTime slice element, got %s", fields[0].(*ASN1Structured).Elements()[0].TaggedString())
+	}
+	if _, ok := fields[1].(*ASN1Structured).Elements()[0].(*ASN1IA5String); !ok {
+		t.Errorf("expected IA5String slice element, got %s", fields[1].(*ASN1Structured).Elements()[0].TaggedString())
+	}
+	entry := fields[2].(*ASN1Structured).Elements()[0].(*ASN1Structured).Elements()
+	if _, ok := entry[0].(*ASN1PrintableString); !ok {
+		t.Errorf("expected PrintableString map key, got %s", entry[0].TaggedString())
+	}
+	if _, ok := entry[1].(*ASN1GeneralizedTime); !ok {
+		t.Errorf("expected GeneralizedTime map value, got %s", entry[1].TaggedString())
+	}
+
+	var decoded TimeHints
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(decoded.Events) != 2 || !decoded.Events["end"].Equal(original.Events["end"]) {
+		t.Errorf("Events = %v, want %v", decoded.Events, original.Events)
+	}
+	if len(decoded.Counts) != 2 || !decoded.Counts[1] || decoded.Counts[2] {
+		t.Errorf("Counts = %v, want %v", decoded.Counts, original.Counts)
+	}
+	if decoded.Names[0] != original.Names[0] {
+		t.Errorf("Names = %v, want %v", decoded.Names, original.Names)
+	}
+
+	// Map encoding is deterministic regardless of iteration order
+	for i := 0; i < 10; i++ {
+		again, err := Marshal(original)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(again) != string(encoded) {
+			t.Fatal("map encoding is not deterministic")
+		}
+	}
+}
+
+func TestChoiceTimeRoundTrip(t *testing.T) {
+	type Message struct {
+		Content interface{} `asn1:"choice"`
+	}
+	original := &Message{Content: time.Date(2080, 5, 5, 5, 5, 5, 0, time.UTC)}
+
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded Message
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	got, ok := decoded.Content.(time.Time)
+	if !ok || !got.Equal(original.Content.(time.Time)) {
+		t.Errorf("Content = %v, want %v", decoded.Content, original.Content)
+	}
+}
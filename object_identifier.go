@@ -2,53 +2,47 @@ package asn1
 
 import (
 	"fmt"
-	"strconv"
+	"math/big"
 	"strings"
 )
 
-// ASN1ObjectIdentifier represents an ASN.1 OBJECT IDENTIFIER
+// ASN1ObjectIdentifier represents an ASN.1 OBJECT IDENTIFIER.
+// Arcs are arbitrary-precision so that values such as the 128-bit UUID arcs
+// under 2.25 (X.667) can be represented.
 type ASN1ObjectIdentifier struct {
-	components []int
+	arcs []*big.Int
 }
 
 // NewObjectIdentifier creates a new ASN1ObjectIdentifier
-func NewObjectIdentifier(components []int) *ASN1ObjectIdentifier {
-	if len(components) < 2 {
-		panic("object identifier must have at least 2 components")
+func NewObjectIdentifier(components []int) (*ASN1ObjectIdentifier, error) {
+	return NewObjectIdentifierFromArcs(intArcs(components))
+}
+
+// NewObjectIdentifierFromArcs creates a new ASN1ObjectIdentifier from arbitrary-precision arcs
+func NewObjectIdentifierFromArcs(arcs []*big.Int) (*ASN1ObjectIdentifier, error) {
+	if len(arcs) < 2 {
+		return nil, fmt.Errorf("object identifier must have at least 2 components")
 	}
-	if components[0] < 0 || components[0] > 2 {
-		panic("first component must be 0, 1, or 2")
+	if err := checkArcs(arcs); err != nil {
+		return nil, err
 	}
-	if components[0] < 2 && (components[1] < 0 || components[1] > 39) {
-		panic("second component must be 0-39 when first component is 0 or 1")
+	if arcs[0].Cmp(big.NewInt(2)) > 0 {
+		return nil, fmt.Errorf("first component must be 0, 1, or 2")
 	}
-	
-	// Create a copy to prevent external modification
-	copied := make([]int, len(components))
-	copy(copied, components)
-	return &ASN1ObjectIdentifier{components: copied}
+	if arcs[0].Cmp(big.NewInt(2)) < 0 && arcs[1].Cmp(big.NewInt(39)) > 0 {
+		return nil, fmt.Errorf("second component must be 0-39 when first component is 0 or 1")
+	}
+
+	return &ASN1ObjectIdentifier{arcs: copyArcs(arcs)}, nil
 }
 
 // NewObjectIdentifierFromString creates a new ASN1ObjectIdentifier from a dot-separated string
 func NewObjectIdentifierFromString(oid string) (*ASN1ObjectIdentifier, error) {
-	parts := strings.Split(oid, ".")
-	if len(parts) < 2 {
-		return nil, fmt.Errorf("object identifier must have at least 2 components")
-	}
-	
-	components := make([]int, len(parts))
-	for i, part := range parts {
-		val, err := strconv.Atoi(part)
-		if err != nil {
-			return nil, fmt.Errorf("invalid component %q: %w", part, err)
-		}
-		if val < 0 {
-			return nil, fmt.Errorf("component cannot be negative: %d", val)
-		}
-		components[i] = val
+	arcs, err := parseArcs(oid)
+	if err != nil {
+		return nil, err
 	}
-	
-	return NewObjectIdentifier(components), nil
+	return NewObjectIdentifierFromArcs(arcs)
 }
 
 // NewObjectIdentifierFromStringUnchecked creates a new ASN1ObjectIdentifier from a dot-separated string
@@ -58,42 +52,43 @@ func NewObjectIdentifierFromStringUnchecked(oid string) *ASN1ObjectIdentifier {
 	if err != nil {
 		panic(fmt.Sprintf("invalid OID string %q: %v", oid, err))
 	}
+
 	return result
 }
 
-// Components returns the OID components
+// Components returns the OID components.
+// It returns nil if any arc does not fit in an int; use Arcs for such OIDs.
 func (o *ASN1ObjectIdentifier) Components() []int {
-	// Return a copy to prevent external modification
-	result := make([]int, len(o.components))
-	copy(result, o.components)
-	return result
+	return arcsToInts(o.arcs)
 }
 
-// String returns the dot-separated string representation
+// Arcs returns the OID components as arbitrary-precision integers
+func (o *ASN1ObjectIdentifier) Arcs() []*big.Int {
+	return copyArcs(o.arcs)
+}
+
+// String returns the dot-separated string representation,
+// followed by the registered name if there is one
 func (o *ASN1ObjectIdentifier) String() string {
-	parts := make([]string, len(o.components))
-	for i, component := range o.components {
-		parts[i] = strconv.Itoa(component)
-	}
-	return fmt.Sprintf("OBJECT IDENTIFIER %s", strings.Join(parts, "."))
+	return fmt.Sprintf("OBJECT IDENTIFIER %s", o.namedDotNotation())
 }
 
 // TaggedString returns a string representation with tag information
 func (o *ASN1ObjectIdentifier) TaggedString() string {
-	parts := make([]string, len(o.components))
-	for i, component := range o.components {
-		parts[i] = strconv.Itoa(component)
+	return fmt.Sprintf("%s OBJECT IDENTIFIER: %s", o.Tag().TagString(), o.namedDotNotation())
+}
+
+// namedDotNotation returns the dot notation with the registered name in parentheses
+func (o *ASN1ObjectIdentifier) namedDotNotation() string {
+	if name := o.Name(); name != "" {
+		return fmt.Sprintf("%s (%s)", o.DotNotation(), name)
 	}
-	return fmt.Sprintf("%s OBJECT IDENTIFIER: %s", o.Tag().TagString(), strings.Join(parts, "."))
+	return o.DotNotation()
 }
 
 // DotNotation returns just the dot-separated string without the type prefix
 func (o *ASN1ObjectIdentifier) DotNotation() string {
-	parts := make([]string, len(o.components))
-	for i, component := range o.components {
-		parts[i] = strconv.Itoa(component)
-	}
-	return strings.Join(parts, ".")
+	return formatArcs(o.arcs)
 }
 
 // Tag returns the ASN.1 tag for OBJECT IDENTIFIER
@@ -103,113 +98,165 @@ func (o *ASN1ObjectIdentifier) Tag() Tag {
 
 // Encode returns the BER encoding of the object identifier
 func (o *ASN1ObjectIdentifier) Encode() ([]byte, error) {
-	if len(o.components) < 2 {
+	if len(o.arcs) < 2 {
 		return nil, fmt.Errorf("object identifier must have at least 2 components")
 	}
-	
-	var content []byte
-	
-	// First subidentifier combines the first two components
-	firstSubid := o.components[0]*40 + o.components[1]
-	content = append(content, encodeSubidentifier(firstSubid)...)
-	
+
+	// First subidentifier combines the first two components as X*40+Y.
+	// For X=2 the second arc is unbounded, so this may exceed 80.
+	firstSubid := new(big.Int).Mul(o.arcs[0], big.NewInt(40))
+	firstSubid.Add(firstSubid, o.arcs[1])
+	content := encodeSubidentifier(firstSubid)
+
 	// Remaining components are encoded individually
-	for i := 2; i < len(o.components); i++ {
-		content = append(content, encodeSubidentifier(o.components[i])...)
+	for _, arc := range o.arcs[2:] {
+		content = append(content, encodeSubidentifier(arc)...)
 	}
-	
+
 	return EncodeTLV(o.Tag(), content)
 }
 
+// Equal reports whether two object identifiers have the same arcs
+func (o *ASN1ObjectIdentifier) Equal(other *ASN1ObjectIdentifier) bool {
+	return other != nil && arcsEqual(o.arcs, other.arcs)
+}
+
 // encodeSubidentifier encodes a single subidentifier using base-128 encoding
-func encodeSubidentifier(value int) []byte {
-	if value == 0 {
+func encodeSubidentifier(value *big.Int) []byte {
+	if value.Sign() == 0 {
 		return []byte{0}
 	}
-	
-	var result []byte
-	for value > 0 {
-		result = append([]byte{byte(value & 0x7F)}, result...)
-		value >>= 7
+
+	// Emit 7 bits per byte, most significant group first
+	groups := (value.BitLen() + 6) / 7
+	result := make([]byte, groups)
+	for i := 0; i < groups; i++ {
+		var b byte
+		for bit := 0; bit < 7; bit++ {
+			b |= byte(value.Bit((groups-1-i)*7+bit)) << bit
+		}
+		result[i] = b
 	}
-	
+
 	// Set the continuation bit (bit 7) for all bytes except the last
 	for i := 0; i < len(result)-1; i++ {
 		result[i] |= 0x80
 	}
-	
+
 	return result
 }
 
-// DecodeObjectIdentifierValue decodes an object identifier value from raw bytes
+// DecodeObjectIdentifierValue decodes an object identifier value from raw bytes.
+// It returns an error if an arc does not fit in an int; use
+// DecodeObjectIdentifierArcs for OIDs with large arcs.
 func DecodeObjectIdentifierValue(data []byte) ([]int, error) {
+	arcs, err := DecodeObjectIdentifierArcs(data)
+	if err != nil {
+		return nil, err
+	}
+	components := arcsToInts(arcs)
+	if components == nil {
+		return nil, fmt.Errorf("object identifier arc does not fit in an int")
+	}
+	return components, nil
+}
+
+// DecodeObjectIdentifierArcs decodes an object identifier value into arbitrary-precision arcs
+func DecodeObjectIdentifierArcs(data []byte) ([]*big.Int, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("object identifier value cannot be empty")
 	}
-	
-	var components []int
-	offset := 0
-	
+
 	// First subidentifier combines the first two components
-	firstSubid, consumed, err := decodeSubidentifier(data[offset:])
+	firstSubid, offset, err := decodeSubidentifier(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode first subidentifier: %w", err)
 	}
-	offset += consumed
-	
-	// Split the first subidentifier into first two components
-	if firstSubid < 40 {
-		components = []int{0, firstSubid}
-	} else if firstSubid < 80 {
-		components = []int{1, firstSubid - 40}
+
+	// Split the first subidentifier into first two components.
+	// Values of 80 and above always belong to the joint-iso-itu-t (2) arc.
+	var arcs []*big.Int
+	if firstSubid.Cmp(big.NewInt(80)) < 0 {
+		first := firstSubid.Int64() / 40
+		arcs = []*big.Int{big.NewInt(first), big.NewInt(firstSubid.Int64() - first*40)}
 	} else {
-		components = []int{2, firstSubid - 80}
+		arcs = []*big.Int{big.NewInt(2), firstSubid.Sub(firstSubid, big.NewInt(80))}
 	}
-	
+
 	// Decode remaining subidentifiers
+	rest, err := decodeSubidentifiers(data[offset:], offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(arcs, rest...), nil
+}
+
+// decodeSubidentifiers decodes a run of subidentifiers; base is the offset
+// of data within the content octets and is only used in error messages
+func decodeSubidentifiers(data []byte, base int) ([]*big.Int, error) {
+	var arcs []*big.Int
+	offset := 0
 	for offset < len(data) {
 		subid, consumed, err := decodeSubidentifier(data[offset:])
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode subidentifier at offset %d: %w", offset, err)
+			return nil, fmt.Errorf("failed to decode subidentifier at offset %d: %w", base+offset, err)
 		}
-		components = append(components, subid)
+		arcs = append(arcs, subid)
 		offset += consumed
 	}
-	
-	return components, nil
+	return arcs, nil
 }
 
 // decodeSubidentifier decodes a single subidentifier from base-128 encoding
-func decodeSubidentifier(data []byte) (int, int, error) {
+func decodeSubidentifier(data []byte) (*big.Int, int, error) {
 	if len(data) == 0 {
-		return 0, 0, fmt.Errorf("empty data")
+		return nil, 0, fmt.Errorf("empty data")
 	}
-	
-	value := 0
-	offset := 0
-	
-	for {
-		if offset >= len(data) {
-			return 0, 0, fmt.Errorf("incomplete subidentifier")
+
+	// X.690 8.19.2: the leading octet shall not be 0x80
+	if data[0] == 0x80 {
+		return nil, 0, fmt.Errorf("subidentifier is not minimally encoded")
+	}
+
+	// Find the last byte first so the value can be built in one pass
+	end := 0
+	for data[end]&0x80 != 0 {
+		end++
+		if end >= len(data) {
+			return nil, 0, fmt.Errorf("incomplete subidentifier")
 		}
-		
-		b := data[offset]
-		offset++
-		
-		// Check for overflow
-		if value > (1<<(32-7))-1 {
-			return 0, 0, fmt.Errorf("subidentifier too large")
+	}
+
+	// Small values (up to 9 bytes, 63 bits) avoid the big.Int bit loop
+	if end < 9 {
+		var value uint64
+		for _, b := range data[:end+1] {
+			value = value<<7 | uint64(b&0x7F)
 		}
-		
-		value = (value << 7) | int(b&0x7F)
-		
-		// If bit 7 is not set, this is the last byte
-		if (b & 0x80) == 0 {
-			break
+		return new(big.Int).SetUint64(value), end + 1, nil
+	}
+
+	// Pack the 7-bit groups into bytes, least significant first, and set
+	// them at once: shifting a growing big.Int per group is quadratic
+	buf := make([]byte, (7*(end+1)+7)/8)
+	i := len(buf) - 1
+	var acc uint
+	var bits uint
+	for j := end; j >= 0; j-- {
+		acc |= uint(data[j]&0x7F) << bits
+		bits += 7
+		for bits >= 8 {
+			buf[i] = byte(acc)
+			i--
+			acc >>= 8
+			bits -= 8
 		}
 	}
-	
-	return value, offset, nil
+	if bits > 0 {
+		buf[i] = byte(acc)
+	}
+	return new(big.Int).SetBytes(buf), end + 1, nil
 }
 
 // DecodeObjectIdentifier decodes an ASN1ObjectIdentifier from BER-encoded data
@@ -223,10 +270,231 @@ func DecodeObjectIdentifier(data []byte) (*ASN1ObjectIdentifier, int, error) {
 		return nil, 0, fmt.Errorf("expected OBJECT IDENTIFIER tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
 	}
 
-	components, err := DecodeObjectIdentifierValue(asn1Value.value)
+	arcs, err := DecodeObjectIdentifierArcs(asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &ASN1ObjectIdentifier{arcs: arcs}, consumed, nil
+}
+
+// Join returns the object identifier formed by appending the arcs of rel to o
+func (o *ASN1ObjectIdentifier) Join(rel *ASN1RelativeOID) *ASN1ObjectIdentifier {
+	arcs := make([]*big.Int, 0, len(o.arcs)+len(rel.arcs))
+	arcs = append(arcs, o.arcs...)
+	arcs = append(arcs, rel.arcs...)
+	return &ASN1ObjectIdentifier{arcs: copyArcs(arcs)}
+}
+
+// RelativeTo returns the arcs of o that follow base.
+// It returns an error unless base is a proper prefix of o.
+func (o *ASN1ObjectIdentifier) RelativeTo(base *ASN1ObjectIdentifier) (*ASN1RelativeOID, error) {
+	if len(base.arcs) >= len(o.arcs) || !arcsEqual(o.arcs[:len(base.arcs)], base.arcs) {
+		return nil, fmt.Errorf("%s is not below %s", o.DotNotation(), base.DotNotation())
+	}
+	return &ASN1RelativeOID{arcs: copyArcs(o.arcs[len(base.arcs):])}, nil
+}
+
+// ASN1RelativeOID represents an ASN.1 RELATIVE-OID: the trailing arcs of an
+// OBJECT IDENTIFIER whose base is known from context
+type ASN1RelativeOID struct {
+	arcs []*big.Int
+}
+
+// NewRelativeOID creates a new ASN1RelativeOID
+func NewRelativeOID(components []int) (*ASN1RelativeOID, error) {
+	return NewRelativeOIDFromArcs(intArcs(components))
+}
+
+// NewRelativeOIDFromArcs creates a new ASN1RelativeOID from arbitrary-precision arcs
+func NewRelativeOIDFromArcs(arcs []*big.Int) (*ASN1RelativeOID, error) {
+	if len(arcs) == 0 {
+		return nil, fmt.Errorf("relative object identifier must have at least 1 component")
+	}
+	if err := checkArcs(arcs); err != nil {
+		return nil, err
+	}
+	return &ASN1RelativeOID{arcs: copyArcs(arcs)}, nil
+}
+
+// NewRelativeOIDFromString creates a new ASN1RelativeOID from a dot-separated string
+func NewRelativeOIDFromString(oid string) (*ASN1RelativeOID, error) {
+	arcs, err := parseArcs(oid)
+	if err != nil {
+		return nil, err
+	}
+	return NewRelativeOIDFromArcs(arcs)
+}
+
+// Components returns the relative OID components.
+// It returns nil if any arc does not fit in an int; use Arcs for such values.
+func (r *ASN1RelativeOID) Components() []int {
+	return arcsToInts(r.arcs)
+}
+
+// Arcs returns the relative OID components as arbitrary-precision integers
+func (r *ASN1RelativeOID) Arcs() []*big.Int {
+	return copyArcs(r.arcs)
+}
+
+// DotNotation returns just the dot-separated string without the type prefix
+func (r *ASN1RelativeOID) DotNotation() string {
+	return formatArcs(r.arcs)
+}
+
+// String returns the dot-separated string representation
+func (r *ASN1RelativeOID) String() string {
+	return fmt.Sprintf("RELATIVE-OID %s", r.DotNotation())
+}
+
+// TaggedString returns a string representation with tag information
+func (r *ASN1RelativeOID) TaggedString() string {
+	return fmt.Sprintf("%s RELATIVE-OID: %s", r.Tag().TagString(), r.DotNotation())
+}
+
+// Tag returns the ASN.1 tag for RELATIVE-OID
+func (r *ASN1RelativeOID) Tag() Tag {
+	return NewUniversalTag(TagRelativeOID, false)
+}
+
+// Encode returns the BER encoding of the relative object identifier.
+// Unlike OBJECT IDENTIFIER, every arc is its own subidentifier.
+func (r *ASN1RelativeOID) Encode() ([]byte, error) {
+	if len(r.arcs) == 0 {
+		return nil, fmt.Errorf("relative object identifier must have at least 1 component")
+	}
+
+	var content []byte
+	for _, arc := range r.arcs {
+		content = append(content, encodeSubidentifier(arc)...)
+	}
+
+	return EncodeTLV(r.Tag(), content)
+}
+
+// DecodeRelativeOIDValue decodes a relative object identifier value from raw bytes.
+// It returns an error if an arc does not fit in an int.
+func DecodeRelativeOIDValue(data []byte) ([]int, error) {
+	arcs, err := DecodeRelativeOIDArcs(data)
+	if err != nil {
+		return nil, err
+	}
+	components := arcsToInts(arcs)
+	if components == nil {
+		return nil, fmt.Errorf("relative object identifier arc does not fit in an int")
+	}
+	return components, nil
+}
+
+// DecodeRelativeOIDArcs decodes a relative object identifier value into arbitrary-precision arcs
+func DecodeRelativeOIDArcs(data []byte) ([]*big.Int, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("relative object identifier value cannot be empty")
+	}
+	return decodeSubidentifiers(data, 0)
+}
+
+// DecodeRelativeOID decodes an ASN1RelativeOID from BER-encoded data
+func DecodeRelativeOID(data []byte) (*ASN1RelativeOID, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagRelativeOID {
+		return nil, 0, fmt.Errorf("expected RELATIVE-OID tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	arcs, err := DecodeRelativeOIDArcs(asn1Value.value)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	return NewObjectIdentifier(components), consumed, nil
-}
\ No newline at end of file
+	return &ASN1RelativeOID{arcs: arcs}, consumed, nil
+}
+
+// parseArcs parses a dot-separated list of decimal arcs
+func parseArcs(oid string) ([]*big.Int, error) {
+	if oid == "" {
+		return nil, fmt.Errorf("object identifier string cannot be empty")
+	}
+
+	parts := strings.Split(oid, ".")
+	arcs := make([]*big.Int, len(parts))
+	for i, part := range parts {
+		if !isIntegerLabel(part) {
+			return nil, fmt.Errorf("invalid component %q", part)
+		}
+		arc, ok := new(big.Int).SetString(part, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid component %q", part)
+		}
+		arcs[i] = arc
+	}
+	return arcs, nil
+}
+
+// checkArcs rejects nil and negative arcs
+func checkArcs(arcs []*big.Int) error {
+	for _, arc := range arcs {
+		if arc == nil {
+			return fmt.Errorf("component cannot be nil")
+		}
+		if arc.Sign() < 0 {
+			return fmt.Errorf("component cannot be negative: %s", arc)
+		}
+	}
+	return nil
+}
+
+// intArcs converts int components to arcs
+func intArcs(components []int) []*big.Int {
+	arcs := make([]*big.Int, len(components))
+	for i, component := range components {
+		arcs[i] = big.NewInt(int64(component))
+	}
+	return arcs
+}
+
+// arcsToInts converts arcs to ints, returning nil if any arc does not fit
+func arcsToInts(arcs []*big.Int) []int {
+	components := make([]int, len(arcs))
+	for i, arc := range arcs {
+		if !arc.IsInt64() || int64(int(arc.Int64())) != arc.Int64() {
+			return nil
+		}
+		components[i] = int(arc.Int64())
+	}
+	return components
+}
+
+// copyArcs returns a deep copy so callers cannot modify the stored arcs
+func copyArcs(arcs []*big.Int) []*big.Int {
+	copied := make([]*big.Int, len(arcs))
+	for i, arc := range arcs {
+		copied[i] = new(big.Int).Set(arc)
+	}
+	return copied
+}
+
+// formatArcs returns the dot-separated decimal form of arcs
+func formatArcs(arcs []*big.Int) string {
+	parts := make([]string, len(arcs))
+	for i, arc := range arcs {
+		parts[i] = arc.String()
+	}
+	return strings.Join(parts, ".")
+}
+
+// arcsEqual reports whether two arc lists are identical
+func arcsEqual(a, b []*big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Cmp(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
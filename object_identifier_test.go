@@ -0,0 +1,153 @@
+package asn1
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestObjectIdentifierLargeArcs(t *testing.T) {
+	tests := []struct {
+		oid  string
+		want []byte
+	}{
+		{"2.40", []byte{0x06, 0x01, 0x78}},
+		{"2.999.3", []byte{0x06, 0x03, 0x88, 0x37, 0x03}},
+		{"2.25.329800735698586629295641978511506172918", append([]byte{0x06, 0x14, 0x69},
+			0x83, 0xF0, 0x9D, 0xA7, 0xEB, 0xCF, 0xDE, 0xE0, 0xC7, 0xA1, 0xA7, 0xB2, 0xC0, 0x94, 0x8C, 0xC8, 0xF9, 0xD7, 0x76)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.oid, func(t *testing.T) {
+			oid, err := NewObjectIdentifierFromString(tt.oid)
+			if err != nil {
+				t.Fatalf("NewObjectIdentifierFromString() error = %v", err)
+			}
+			encoded, err := oid.Encode()
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(encoded, tt.want) {
+				t.Errorf("Encode() = %x, want %x", encoded, tt.want)
+			}
+
+			decoded, _, err := DecodeObjectIdentifier(encoded)
+			if err != nil {
+				t.Fatalf("DecodeObjectIdentifier() error = %v", err)
+			}
+			if decoded.DotNotation() != tt.oid || !decoded.Equal(oid) {
+				t.Errorf("DecodeObjectIdentifier() = %s, want %s", decoded.DotNotation(), tt.oid)
+			}
+		})
+	}
+
+	uuid, _ := NewObjectIdentifierFromString("2.25.329800735698586629295641978511506172918")
+	if uuid.Components() != nil {
+		t.Error("Components() should be nil when an arc does not fit in an int")
+	}
+	want, _ := new(big.Int).SetString("329800735698586629295641978511506172918", 10)
+	if arcs := uuid.Arcs(); arcs[2].Cmp(want) != 0 {
+		t.Errorf("Arcs()[2] = %s, want %s", arcs[2], want)
+	}
+	encoded, _ := uuid.Encode()
+	if _, err := DecodeObjectIdentifierValue(encoded[2:]); err == nil {
+		t.Error("DecodeObjectIdentifierValue() should report arcs that do not fit in an int")
+	}
+}
+
+func TestObjectIdentifierConstructorErrors(t *testing.T) {
+	invalid := [][]int{nil, {1}, {3, 1}, {1, 40}, {0, -1}, {1, 2, -3}}
+	for _, components := range invalid {
+		if _, err := NewObjectIdentifier(components); err == nil {
+			t.Errorf("NewObjectIdentifier(%v) expected error", components)
+		}
+	}
+
+	for _, input := range []string{"", "1", "3.1", "1.40", "1.2.", "1.+2", "1.2.x", "-1.2"} {
+		if _, err := NewObjectIdentifierFromString(input); err == nil {
+			t.Errorf("NewObjectIdentifierFromString(%q) expected error", input)
+		}
+	}
+
+	if _, err := NewRelativeOID(nil); err == nil {
+		t.Error("NewRelativeOID(nil) expected error")
+	}
+	if _, err := NewRelativeOIDFromArcs([]*big.Int{big.NewInt(-1)}); err == nil {
+		t.Error("NewRelativeOIDFromArcs() expected error for negative arc")
+	}
+}
+
+func TestObjectIdentifierMalformedInput(t *testing.T) {
+	inputs := [][]byte{
+		{0x06, 0x00},             // empty value
+		{0x06, 0x01, 0x81},       // truncated subidentifier
+		{0x06, 0x02, 0x80, 0x01}, // non-minimal first subidentifier
+		{0x06, 0x03, 0x2A, 0x80, 0x01},
+		{0x06, 0x02, 0x2A, 0xFF},
+	}
+	for _, input := range inputs {
+		if _, _, err := DecodeObjectIdentifier(input); err == nil {
+			t.Errorf("DecodeObjectIdentifier(%x) expected error", input)
+		}
+	}
+
+	// The generic decoder keeps malformed values as raw ASN1Value
+	value, _, err := DecodeTLV([]byte{0x06, 0x01, 0x81})
+	if err != nil {
+		t.Fatalf("DecodeTLV() error = %v", err)
+	}
+	if _, ok := convertPrimitiveValue(value).(*ASN1Value); !ok {
+		t.Error("expected malformed OID to stay an ASN1Value")
+	}
+}
+
+func TestObjectIdentifierHugeArc(t *testing.T) {
+	// A 256 KiB arc: 2.(2^(7*n) - 1), all groups 0x7F
+	const groups = 256 << 10
+	content := make([]byte, 0, groups+1)
+	content = append(content, 0x50)
+	for i := 0; i < groups-1; i++ {
+		content = append(content, 0xFF)
+	}
+	content = append(content, 0x7F)
+	header, _ := EncodeLength(len(content))
+	data := append(append([]byte{0x06}, header...), content...)
+
+	start := time.Now()
+	obj, _, err := DecodeWithOptions(data, nil)
+	if err != nil {
+		t.Fatalf("DecodeWithOptions() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("decoding took %v, want linear time", elapsed)
+	}
+
+	arcs := obj.(*ASN1ObjectIdentifier).Arcs()
+	want := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 7*groups), big.NewInt(1))
+	if len(arcs) != 3 || arcs[2].Cmp(want) != 0 {
+		t.Fatalf("decoded arc has %d bits, want %d", arcs[len(arcs)-1].BitLen(), want.BitLen())
+	}
+	encoded, err := obj.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if !bytes.Equal(encoded, data) {
+		t.Error("Encode() does not reproduce the input")
+	}
+
+	// Arcs of every group count up to a few bytes past the uint64 fast path
+	for n := 1; n <= 24; n++ {
+		arc := new(big.Int).Lsh(big.NewInt(1), uint(7*n-1))
+		arc.Add(arc, big.NewInt(int64(n)))
+		oid, err := NewObjectIdentifierFromArcs([]*big.Int{big.NewInt(2), arc})
+		if err != nil {
+			t.Fatalf("NewObjectIdentifierFromArcs() error = %v", err)
+		}
+		encoded, _ := oid.Encode()
+		decoded, _, err := DecodeObjectIdentifier(encoded)
+		if err != nil || !decoded.Equal(oid) {
+			t.Errorf("%d groups: decoded %v, err %v, want %s", n, decoded, err, oid.DotNotation())
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package asn1
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ASN1OIDIRI represents an ASN.1 OID-IRI: an object identifier written as a
+// path of Unicode labels such as "/ISO/Registration_Authority/19785.CBEFF"
+type ASN1OIDIRI struct {
+	value string
+}
+
+// NewOIDIRI creates a new ASN1OIDIRI.
+// The value must start with "/" and contain at least one valid label.
+func NewOIDIRI(value string) (*ASN1OIDIRI, error) {
+	if !strings.HasPrefix(value, "/") {
+		return nil, fmt.Errorf("OID-IRI must start with \"/\": %q", value)
+	}
+	if err := validateIRIArcs(value[1:]); err != nil {
+		return nil, fmt.Errorf("invalid OID-IRI %q: %w", value, err)
+	}
+	return &ASN1OIDIRI{value: value}, nil
+}
+
+// NewOIDIRIFromObjectIdentifier creates the numeric OID-IRI for an object identifier
+func NewOIDIRIFromObjectIdentifier(oid *ASN1ObjectIdentifier) *ASN1OIDIRI {
+	return &ASN1OIDIRI{value: "/" + strings.ReplaceAll(oid.DotNotation(), ".", "/")}
+}
+
+// Value returns the IRI string
+func (i *ASN1OIDIRI) Value() string {
+	return i.value
+}
+
+// Join returns the OID-IRI formed by appending the arcs of rel to i
+func (i *ASN1OIDIRI) Join(rel *ASN1RelativeOIDIRI) *ASN1OIDIRI {
+	return &ASN1OIDIRI{value: i.value + "/" + rel.value}
+}
+
+// ObjectIdentifier converts the IRI to an object identifier.
+// This only succeeds when every arc is an integer label.
+func (i *ASN1OIDIRI) ObjectIdentifier() (*ASN1ObjectIdentifier, error) {
+	if !isIntegerPath(i.value[1:]) {
+		return nil, fmt.Errorf("OID-IRI %q has non-integer labels", i.value)
+	}
+	oid, err := NewObjectIdentifierFromString(strings.ReplaceAll(i.value[1:], "/", "."))
+	if err != nil {
+		return nil, fmt.Errorf("OID-IRI %q is not a valid object identifier: %w", i.value, err)
+	}
+	return oid, nil
+}
+
+// String returns a string representation of the OID-IRI
+func (i *ASN1OIDIRI) String() string {
+	return fmt.Sprintf("OID-IRI %s", i.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (i *ASN1OIDIRI) TaggedString() string {
+	return fmt.Sprintf("%s OID-IRI: %s", i.Tag().TagString(), i.value)
+}
+
+// Tag returns the ASN.1 tag for OID-IRI
+func (i *ASN1OIDIRI) Tag() Tag {
+	return NewUniversalTag(TagOIDIRI, false)
+}
+
+// Encode returns the BER encoding of the OID-IRI
+func (i *ASN1OIDIRI) Encode() ([]byte, error) {
+	return EncodeTLV(i.Tag(), []byte(i.value))
+}
+
+// DecodeOIDIRI decodes an ASN1OIDIRI from BER-encoded data
+func DecodeOIDIRI(data []byte) (*ASN1OIDIRI, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagOIDIRI {
+		return nil, 0, fmt.Errorf("expected OID-IRI tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	iri, err := NewOIDIRI(string(asn1Value.value))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return iri, consumed, nil
+}
+
+// ASN1RelativeOIDIRI represents an ASN.1 RELATIVE-OID-IRI: the trailing
+// labels of an OID-IRI, written without a leading "/"
+type ASN1RelativeOIDIRI struct {
+	value string
+}
+
+// NewRelativeOIDIRI creates a new ASN1RelativeOIDIRI
+func NewRelativeOIDIRI(value string) (*ASN1RelativeOIDIRI, error) {
+	if err := validateIRIArcs(value); err != nil {
+		return nil, fmt.Errorf("invalid RELATIVE-OID-IRI %q: %w", value, err)
+	}
+	return &ASN1RelativeOIDIRI{value: value}, nil
+}
+
+// NewRelativeOIDIRIFromRelativeOID creates the numeric RELATIVE-OID-IRI for a relative OID
+func NewRelativeOIDIRIFromRelativeOID(rel *ASN1RelativeOID) *ASN1RelativeOIDIRI {
+	return &ASN1RelativeOIDIRI{value: strings.ReplaceAll(rel.DotNotation(), ".", "/")}
+}
+
+// Value returns the IRI string
+func (r *ASN1RelativeOIDIRI) Value() string {
+	return r.value
+}
+
+// RelativeOID converts the IRI to a relative OID.
+// This only succeeds when every arc is an integer label.
+func (r *ASN1RelativeOIDIRI) RelativeOID() (*ASN1RelativeOID, error) {
+	if !isIntegerPath(r.value) {
+		return nil, fmt.Errorf("RELATIVE-OID-IRI %q has non-integer labels", r.value)
+	}
+	return NewRelativeOIDFromString(strings.ReplaceAll(r.value, "/", "."))
+}
+
+// String returns a string representation of the RELATIVE-OID-IRI
+func (r *ASN1RelativeOIDIRI) String() string {
+	return fmt.Sprintf("RELATIVE-OID-IRI %s", r.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (r *ASN1RelativeOIDIRI) TaggedString() string {
+	return fmt.Sprintf("%s RELATIVE-OID-IRI: %s", r.Tag().TagString(), r.value)
+}
+
+// Tag returns the ASN.1 tag for RELATIVE-OID-IRI
+func (r *ASN1RelativeOIDIRI) Tag() Tag {
+	return NewUniversalTag(TagRelativeOIDIRI, false)
+}
+
+// Encode returns the BER encoding of the RELATIVE-OID-IRI
+func (r *ASN1RelativeOIDIRI) Encode() ([]byte, error) {
+	return EncodeTLV(r.Tag(), []byte(r.value))
+}
+
+// DecodeRelativeOIDIRI decodes an ASN1RelativeOIDIRI from BER-encoded data
+func DecodeRelativeOIDIRI(data []byte) (*ASN1RelativeOIDIRI, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagRelativeOIDIRI {
+		return nil, 0, fmt.Errorf("expected RELATIVE-OID-IRI tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	iri, err := NewRelativeOIDIRI(string(asn1Value.value))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return iri, consumed, nil
+}
+
+// validateIRIArcs checks a "/"-separated list of arc labels (X.660 section 7.5).
+// Integer labels must not have leading zeros; other labels are made of
+// letters, digits, "-", ".", "_" and "~" and may not look like an integer.
+func validateIRIArcs(arcs string) error {
+	if !utf8.ValidString(arcs) {
+		return fmt.Errorf("not valid UTF-8")
+	}
+	if arcs == "" {
+		return fmt.Errorf("at least one arc is required")
+	}
+	for _, label := range strings.Split(arcs, "/") {
+		if label == "" {
+			return fmt.Errorf("empty arc label")
+		}
+		if isIntegerLabel(label) {
+			if len(label) > 1 && label[0] == '0' {
+				return fmt.Errorf("integer label %q has leading zeros", label)
+			}
+			continue
+		}
+		for _, r := range label {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-._~", r) && r < 0x80 {
+				return fmt.Errorf("invalid character %q in label %q", r, label)
+			}
+		}
+	}
+	return nil
+}
+
+// isIntegerPath reports whether every arc label is an integer label
+func isIntegerPath(arcs string) bool {
+	for _, label := range strings.Split(arcs, "/") {
+		if !isIntegerLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isIntegerLabel reports whether a label consists only of ASCII digits
+func isIntegerLabel(label string) bool {
+	if label == "" {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		if !isDigit(label[i]) {
+			return false
+		}
+	}
+	return true
+}
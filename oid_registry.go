@@ -0,0 +1,280 @@
+package asn1
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// OIDRegistry maps object identifiers to descriptive names and back.
+// It is safe for concurrent use.
+type OIDRegistry struct {
+	mu     sync.RWMutex
+	byOID  map[string]string
+	byName map[string]string
+}
+
+// NewOIDRegistry creates an empty OIDRegistry
+func NewOIDRegistry() *OIDRegistry {
+	return &OIDRegistry{
+		byOID:  make(map[string]string),
+		byName: make(map[string]string),
+	}
+}
+
+// NewDefaultOIDRegistry creates an OIDRegistry holding the built-in PKIX,
+// CMS, LDAP and SNMP names. The result can be extended with Register.
+func NewDefaultOIDRegistry() *OIDRegistry {
+	r := NewOIDRegistry()
+	for _, entry := range builtinOIDNames {
+		if err := r.Register(entry.oid, entry.name); err != nil {
+			panic(fmt.Sprintf("invalid built-in OID name: %v", err))
+		}
+	}
+	return r
+}
+
+// Register associates a dot-separated OID with a name.
+// Re-registering the same pair is allowed; giving an OID or a name a
+// second, different mapping is an error.
+func (r *OIDRegistry) Register(oid, name string) error {
+	parsed, err := NewObjectIdentifierFromString(oid)
+	if err != nil {
+		return fmt.Errorf("invalid OID %q: %w", oid, err)
+	}
+	if name == "" {
+		return fmt.Errorf("name for OID %s cannot be empty", oid)
+	}
+	oid = parsed.DotNotation()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.byOID[oid]; ok && existing != name {
+		return fmt.Errorf("OID %s is already registered as %q", oid, existing)
+	}
+	if existing, ok := r.byName[name]; ok && existing != oid {
+		return fmt.Errorf("name %q is already registered for OID %s", name, existing)
+	}
+	r.byOID[oid] = name
+	r.byName[name] = oid
+	return nil
+}
+
+// Name returns the registered name for an object identifier
+func (r *OIDRegistry) Name(oid *ASN1ObjectIdentifier) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.byOID[oid.DotNotation()]
+	return name, ok
+}
+
+// Lookup returns the object identifier registered under name
+func (r *OIDRegistry) Lookup(name string) (*ASN1ObjectIdentifier, bool) {
+	r.mu.RLock()
+	oid, ok := r.byName[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return NewObjectIdentifierFromStringUnchecked(oid), true
+}
+
+// Names returns all registered names in sorted order
+func (r *OIDRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.byName))
+	for name := range r.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// activeOIDRegistry is the registry consulted by ASN1ObjectIdentifier's
+// String and TaggedString methods
+var activeOIDRegistry atomic.Pointer[OIDRegistry]
+
+func init() {
+	activeOIDRegistry.Store(NewDefaultOIDRegistry())
+}
+
+// SetOIDRegistry replaces the registry used when printing object identifiers.
+// Passing nil disables name lookups.
+func SetOIDRegistry(r *OIDRegistry) {
+	activeOIDRegistry.Store(r)
+}
+
+// CurrentOIDRegistry returns the registry used when printing object identifiers,
+// or nil if name lookups are disabled
+func CurrentOIDRegistry() *OIDRegistry {
+	return activeOIDRegistry.Load()
+}
+
+// LookupOID returns the object identifier registered under name in the current registry
+func LookupOID(name string) (*ASN1ObjectIdentifier, bool) {
+	r := activeOIDRegistry.Load()
+	if r == nil {
+		return nil, false
+	}
+	return r.Lookup(name)
+}
+
+// Name returns the name of the object identifier in the current registry,
+// or "" if it has none
+func (o *ASN1ObjectIdentifier) Name() string {
+	r := activeOIDRegistry.Load()
+	if r == nil {
+		return ""
+	}
+	name, _ := r.Name(o)
+	return name
+}
+
+// builtinOIDNames lists the names shipped with NewDefaultOIDRegistry
+var builtinOIDNames = []struct {
+	oid  string
+	name string
+}{
+	// X.500 attribute types (RFC 5280, RFC 4519)
+	{"2.5.4.0", "objectClass"},
+	{"2.5.4.3", "commonName"},
+	{"2.5.4.4", "surname"},
+	{"2.5.4.5", "serialNumber"},
+	{"2.5.4.6", "countryName"},
+	{"2.5.4.7", "localityName"},
+	{"2.5.4.8", "stateOrProvinceName"},
+	{"2.5.4.9", "streetAddress"},
+	{"2.5.4.10", "organizationName"},
+	{"2.5.4.11", "organizationalUnitName"},
+	{"2.5.4.12", "title"},
+	{"2.5.4.17", "postalCode"},
+	{"2.5.4.31", "member"},
+	{"2.5.4.35", "userPassword"},
+	{"2.5.4.41", "name"},
+	{"2.5.4.42", "givenName"},
+	{"2.5.4.43", "initials"},
+	{"2.5.4.44", "generationQualifier"},
+	{"2.5.4.46", "dnQualifier"},
+	{"2.5.4.49", "distinguishedName"},
+	{"2.5.4.50", "uniqueMember"},
+	{"2.5.4.65", "pseudonym"},
+	{"2.5.4.97", "organizationIdentifier"},
+	{"1.2.840.113549.1.9.1", "emailAddress"},
+
+	// Certificate and CRL extensions (RFC 5280)
+	{"2.5.29.14", "subjectKeyIdentifier"},
+	{"2.5.29.15", "keyUsage"},
+	{"2.5.29.17", "subjectAltName"},
+	{"2.5.29.18", "issuerAltName"},
+	{"2.5.29.19", "basicConstraints"},
+	{"2.5.29.20", "cRLNumber"},
+	{"2.5.29.21", "cRLReason"},
+	{"2.5.29.30", "nameConstraints"},
+	{"2.5.29.31", "cRLDistributionPoints"},
+	{"2.5.29.32", "certificatePolicies"},
+	{"2.5.29.32.0", "anyPolicy"},
+	{"2.5.29.33", "policyMappings"},
+	{"2.5.29.35", "authorityKeyIdentifier"},
+	{"2.5.29.36", "policyConstraints"},
+	{"2.5.29.37", "extKeyUsage"},
+	{"2.5.29.54", "inhibitAnyPolicy"},
+	{"1.3.6.1.5.5.7.1.1", "authorityInfoAccess"},
+	{"1.3.6.1.5.5.7.48.1", "ocsp"},
+	{"1.3.6.1.5.5.7.48.2", "caIssuers"},
+
+	// Extended key usages (RFC 5280)
+	{"1.3.6.1.5.5.7.3.1", "serverAuth"},
+	{"1.3.6.1.5.5.7.3.2", "clientAuth"},
+	{"1.3.6.1.5.5.7.3.3", "codeSigning"},
+	{"1.3.6.1.5.5.7.3.4", "emailProtection"},
+	{"1.3.6.1.5.5.7.3.8", "timeStamping"},
+	{"1.3.6.1.5.5.7.3.9", "OCSPSigning"},
+
+	// Signature, key and digest algorithms (RFC 3279, RFC 4055, RFC 5480, RFC 8410)
+	{"1.2.840.113549.1.1.1", "rsaEncryption"},
+	{"1.2.840.113549.1.1.5", "sha1WithRSAEncryption"},
+	{"1.2.840.113549.1.1.7", "rsaesOaep"},
+	{"1.2.840.113549.1.1.8", "mgf1"},
+	{"1.2.840.113549.1.1.10", "rsassaPss"},
+	{"1.2.840.113549.1.1.11", "sha256WithRSAEncryption"},
+	{"1.2.840.113549.1.1.12", "sha384WithRSAEncryption"},
+	{"1.2.840.113549.1.1.13", "sha512WithRSAEncryption"},
+	{"1.2.840.10040.4.1", "dsa"},
+	{"1.2.840.10045.2.1", "ecPublicKey"},
+	{"1.2.840.10045.3.1.7", "prime256v1"},
+	{"1.2.840.10045.4.3.2", "ecdsa-with-SHA256"},
+	{"1.2.840.10045.4.3.3", "ecdsa-with-SHA384"},
+	{"1.2.840.10045.4.3.4", "ecdsa-with-SHA512"},
+	{"1.3.132.0.34", "secp384r1"},
+	{"1.3.132.0.35", "secp521r1"},
+	{"1.3.101.110", "X25519"},
+	{"1.3.101.112", "Ed25519"},
+	{"1.3.101.113", "Ed448"},
+	{"1.3.14.3.2.26", "sha1"},
+	{"2.16.840.1.101.3.4.2.1", "sha256"},
+	{"2.16.840.1.101.3.4.2.2", "sha384"},
+	{"2.16.840.1.101.3.4.2.3", "sha512"},
+	{"2.16.840.1.101.3.4.1.2", "aes128-CBC"},
+	{"2.16.840.1.101.3.4.1.6", "aes128-GCM"},
+	{"2.16.840.1.101.3.4.1.42", "aes256-CBC"},
+	{"2.16.840.1.101.3.4.1.46", "aes256-GCM"},
+
+	// CMS content types and attributes (RFC 5652, RFC 3161, RFC 5035)
+	{"1.2.840.113549.1.7.1", "data"},
+	{"1.2.840.113549.1.7.2", "signedData"},
+	{"1.2.840.113549.1.7.3", "envelopedData"},
+	{"1.2.840.113549.1.7.5", "digestedData"},
+	{"1.2.840.113549.1.7.6", "encryptedData"},
+	{"1.2.840.113549.1.9.16.1.2", "authData"},
+	{"1.2.840.113549.1.9.16.1.4", "tstInfo"},
+	{"1.2.840.113549.1.9.3", "contentType"},
+	{"1.2.840.113549.1.9.4", "messageDigest"},
+	{"1.2.840.113549.1.9.5", "signingTime"},
+	{"1.2.840.113549.1.9.6", "countersignature"},
+	{"1.2.840.113549.1.9.7", "challengePassword"},
+	{"1.2.840.113549.1.9.14", "extensionRequest"},
+	{"1.2.840.113549.1.9.15", "smimeCapabilities"},
+	{"1.2.840.113549.1.9.16.2.47", "signingCertificateV2"},
+
+	// LDAP attributes, object classes, controls and extended operations (RFC 4519, RFC 2798, RFC 4511)
+	{"0.9.2342.19200300.100.1.1", "uid"},
+	{"0.9.2342.19200300.100.1.3", "mail"},
+	{"0.9.2342.19200300.100.1.25", "domainComponent"},
+	{"2.5.6.0", "top"},
+	{"2.5.6.6", "person"},
+	{"2.16.840.1.113730.3.2.2", "inetOrgPerson"},
+	{"1.2.840.113556.1.4.319", "pagedResults"},
+	{"1.2.840.113556.1.4.473", "sortRequest"},
+	{"1.3.6.1.4.1.1466.20037", "startTLS"},
+	{"1.3.6.1.4.1.4203.1.11.1", "passwordModify"},
+	{"1.3.6.1.4.1.4203.1.11.3", "whoAmI"},
+
+	// SNMP MIB-2 (RFC 1213, RFC 3418)
+	{"1.3.6.1", "internet"},
+	{"1.3.6.1.2.1", "mib-2"},
+	{"1.3.6.1.2.1.1", "system"},
+	{"1.3.6.1.2.1.1.1", "sysDescr"},
+	{"1.3.6.1.2.1.1.2", "sysObjectID"},
+	{"1.3.6.1.2.1.1.3", "sysUpTime"},
+	{"1.3.6.1.2.1.1.4", "sysContact"},
+	{"1.3.6.1.2.1.1.5", "sysName"},
+	{"1.3.6.1.2.1.1.6", "sysLocation"},
+	{"1.3.6.1.2.1.1.7", "sysServices"},
+	{"1.3.6.1.2.1.2", "interfaces"},
+	{"1.3.6.1.2.1.2.1", "ifNumber"},
+	{"1.3.6.1.2.1.2.2", "ifTable"},
+	{"1.3.6.1.2.1.4", "ip"},
+	{"1.3.6.1.2.1.6", "tcp"},
+	{"1.3.6.1.2.1.7", "udp"},
+	{"1.3.6.1.2.1.11", "snmp"},
+	{"1.3.6.1.2.1.31.1.1", "ifXTable"},
+	{"1.3.6.1.4.1", "enterprises"},
+	{"1.3.6.1.6.3.1.1.4.1", "snmpTrapOID"},
+	{"1.3.6.1.6.3.1.1.5.1", "coldStart"},
+	{"1.3.6.1.6.3.1.1.5.2", "warmStart"},
+	{"1.3.6.1.6.3.1.1.5.3", "linkDown"},
+	{"1.3.6.1.6.3.1.1.5.4", "linkUp"},
+	{"1.3.6.1.6.3.1.1.5.5", "authenticationFailure"},
+}
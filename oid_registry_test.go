@@ -0,0 +1,78 @@
+package asn1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultOIDRegistry(t *testing.T) {
+	oid := NewObjectIdentifierFromStringUnchecked("1.2.840.113549.1.1.11")
+	if got := oid.String(); got != "OBJECT IDENTIFIER 1.2.840.113549.1.1.11 (sha256WithRSAEncryption)" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := oid.TaggedString(); got != "[UNIVERSAL 6] OBJECT IDENTIFIER: 1.2.840.113549.1.1.11 (sha256WithRSAEncryption)" {
+		t.Errorf("TaggedString() = %q", got)
+	}
+	if oid.DotNotation() != "1.2.840.113549.1.1.11" {
+		t.Errorf("DotNotation() should not include the name, got %q", oid.DotNotation())
+	}
+
+	cn, ok := LookupOID("commonName")
+	if !ok || cn.DotNotation() != "2.5.4.3" {
+		t.Errorf("LookupOID(commonName) = %v, %v", cn, ok)
+	}
+	for _, name := range []string{"signedData", "uid", "sysDescr"} {
+		if _, ok := LookupOID(name); !ok {
+			t.Errorf("LookupOID(%q) not found", name)
+		}
+	}
+
+	unknown := NewObjectIdentifierFromStringUnchecked("1.2.3.4")
+	if unknown.Name() != "" || unknown.String() != "OBJECT IDENTIFIER 1.2.3.4" {
+		t.Errorf("unknown OID printed as %q", unknown.String())
+	}
+
+	// The structure printer picks names up through TaggedString
+	seq := NewSequence()
+	seq.Add(NewObjectIdentifierFromStringUnchecked("2.5.4.6"))
+	seq.Add(NewPrintableString("SE"))
+	if !strings.Contains(seq.CompactString(), "2.5.4.6 (countryName)") {
+		t.Errorf("CompactString() = %s", seq.CompactString())
+	}
+}
+
+func TestCustomOIDRegistry(t *testing.T) {
+	previous := CurrentOIDRegistry()
+	defer SetOIDRegistry(previous)
+
+	registry := NewDefaultOIDRegistry()
+	if err := registry.Register("1.3.6.1.4.1.99999.1", "exampleAttribute"); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := registry.Register("1.3.6.1.4.1.99999.1", "exampleAttribute"); err != nil {
+		t.Errorf("re-registering the same pair should succeed: %v", err)
+	}
+	if err := registry.Register("1.3.6.1.4.1.99999.2", "commonName"); err == nil {
+		t.Error("expected error for duplicate name")
+	}
+	if err := registry.Register("2.5.4.3", "cn"); err == nil {
+		t.Error("expected error for duplicate OID")
+	}
+	if err := registry.Register("3.1", "bogus"); err == nil {
+		t.Error("expected error for invalid OID")
+	}
+
+	SetOIDRegistry(registry)
+	oid := NewObjectIdentifierFromStringUnchecked("1.3.6.1.4.1.99999.1")
+	if oid.Name() != "exampleAttribute" {
+		t.Errorf("Name() = %q, want exampleAttribute", oid.Name())
+	}
+
+	SetOIDRegistry(nil)
+	if oid.Name() != "" || oid.String() != "OBJECT IDENTIFIER 1.3.6.1.4.1.99999.1" {
+		t.Errorf("names should be disabled, got %q", oid.String())
+	}
+	if _, ok := LookupOID("commonName"); ok {
+		t.Error("LookupOID should fail with no registry")
+	}
+}
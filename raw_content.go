@@ -0,0 +1,71 @@
+package asn1
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RawContent is a struct field type that Unmarshal fills with the verbatim
+// encoding (tag, length and content) of the element the struct was decoded
+// from. Fields tagged asn1:"raw" on a []byte behave the same way. Neither
+// consumes an element nor is written by Marshal.
+//
+// Re-encoding a decoded value need not reproduce its original bytes, so
+// signatures over a sub-structure, such as a TBSCertificate, must be checked
+// against RawContent.
+type RawContent []byte
+
+var rawContentType = reflect.TypeOf(RawContent{})
+
+// isRawContentField reports whether a struct field receives the raw encoding
+// of its enclosing element
+func isRawContentField(field reflect.StructField, info *fieldInfo) bool {
+	return field.Type == rawContentType || info.Type == "raw"
+}
+
+// setRawContent stores the verbatim encoding of obj in a RawContent field
+func setRawContent(obj ASN1Object, v reflect.Value) error {
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.Uint8 {
+		return fmt.Errorf("raw content field must be []byte, got %v", v.Type())
+	}
+	encoded, err := originalEncoding(obj)
+	if err != nil {
+		return fmt.Errorf("failed to capture raw content: %w", err)
+	}
+	raw := reflect.MakeSlice(v.Type(), len(encoded), len(encoded))
+	reflect.Copy(raw, reflect.ValueOf(encoded))
+	v.Set(raw)
+	return nil
+}
+
+// decodedBytes returns the bytes obj was decoded from, or nil if it was
+// built in memory or modified since
+func decodedBytes(obj ASN1Object) []byte {
+	switch o := obj.(type) {
+	case *ASN1Value:
+		return o.raw
+	case *ASN1Structured:
+		return o.raw
+	}
+	return nil
+}
+
+// originalEncoding returns the bytes obj was decoded from, falling back to
+// encoding it when they are not known. The result must not be modified.
+func originalEncoding(obj ASN1Object) ([]byte, error) {
+	if raw := decodedBytes(obj); raw != nil {
+		return raw, nil
+	}
+	return obj.Encode()
+}
+
+// unmarshaledEncoding is originalEncoding for an object being unmarshaled,
+// which also finds the bytes of primitive objects in the input
+func unmarshaledEncoding(obj ASN1Object, opts *MarshalOptions) ([]byte, error) {
+	if opts != nil {
+		if raw := opts.state.encoding(obj); raw != nil {
+			return raw, nil
+		}
+	}
+	return originalEncoding(obj)
+}
@@ -0,0 +1,76 @@
+package asn1
+
+import (
+	"bytes"
+	"testing"
+)
+
+type tbsRecord struct {
+	Raw     RawContent
+	Serial  int64 `asn1:"integer"`
+	Enabled bool  `asn1:"boolean"`
+}
+
+type signedRecord struct {
+	TBS       tbsRecord `asn1:"sequence"`
+	Signature []byte    `asn1:"octetstring"`
+}
+
+func TestRawContentPreservesOriginalBytes(t *testing.T) {
+	// The TBS part uses a non-minimal length and a BOOLEAN TRUE of 0x01,
+	// neither of which survives re-encoding
+	tbs := []byte{0x30, 0x81, 0x06, 0x02, 0x01, 0x05, 0x01, 0x01, 0x01}
+	data := append([]byte{0x30, 0x0D}, tbs...)
+	data = append(data, 0x04, 0x02, 0xAB, 0xCD)
+
+	var record signedRecord
+	if err := Unmarshal(data, &record); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if record.TBS.Serial != 5 || !record.TBS.Enabled || !bytes.Equal(record.Signature, []byte{0xAB, 0xCD}) {
+		t.Errorf("Unmarshal() = %+v", record)
+	}
+	if !bytes.Equal(record.TBS.Raw, tbs) {
+		t.Errorf("Raw = %x, want %x", []byte(record.TBS.Raw), tbs)
+	}
+
+	// Raw content is not marshaled
+	encoded, err := Marshal(record.TBS)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x06, 0x02, 0x01, 0x05, 0x01, 0x01, 0xFF}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+}
+
+func TestRawOptionWithImplicitTag(t *testing.T) {
+	type inner struct {
+		Raw []byte `asn1:"raw"`
+		ID  int64  `asn1:"integer"`
+	}
+	type outer struct {
+		Inner inner `asn1:"sequence,tag:0"`
+	}
+
+	data := []byte{0x30, 0x05, 0xA0, 0x03, 0x02, 0x01, 0x09}
+	var decoded outer
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Inner.ID != 9 {
+		t.Errorf("ID = %d, want 9", decoded.Inner.ID)
+	}
+	if !bytes.Equal(decoded.Inner.Raw, data[2:]) {
+		t.Errorf("Raw = %x, want %x", decoded.Inner.Raw, data[2:])
+	}
+
+	var wrong struct {
+		Raw string `asn1:"raw"`
+		ID  int64  `asn1:"integer"`
+	}
+	if err := Unmarshal(data[2:], &wrong); err == nil {
+		t.Errorf("expected error for non-[]byte raw field")
+	}
+}
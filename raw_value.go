@@ -0,0 +1,212 @@
+package asn1
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// RawValue holds an undecoded ASN.1 value. It is used for open types (ANY,
+// ANY DEFINED BY, INSTANCE OF values and information object class fields)
+// whose concrete type is only known once another field, usually a sibling
+// OBJECT IDENTIFIER, has been inspected.
+//
+// A RawValue struct field receives the complete element during Unmarshal,
+// including any context-specific tag. When marshaling, FullBytes is written
+// verbatim if set; otherwise the value is encoded from Class, Tag,
+// IsCompound and Bytes.
+type RawValue struct {
+	Class      int    // 0=Universal, 1=Application, 2=Context-specific, 3=Private
+	Tag        int    // tag number
+	IsCompound bool   // true if the encoding is constructed
+	Bytes      []byte // content octets
+	FullBytes  []byte // complete TLV encoding, including tag and length
+}
+
+// NewRawValue captures the encoding of an ASN.1 object as a RawValue.
+// Constructed objects produced by the decoder keep the exact bytes they were
+// decoded from; primitive ones are encoded again, which for BER input may
+// give different bytes. RawValue fields filled by Unmarshal always hold the
+// bytes of the input.
+func NewRawValue(obj ASN1Object) (RawValue, error) {
+	encoded, err := originalEncoding(obj)
+	if err != nil {
+		return RawValue{}, err
+	}
+	return ParseRawValue(encoded)
+}
+
+// ParseRawValue reads a single TLV from data into a RawValue.
+// Trailing data after the first element is an error.
+func ParseRawValue(data []byte) (RawValue, error) {
+	value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return RawValue{}, err
+	}
+	if consumed != len(data) {
+		return RawValue{}, fmt.Errorf("trailing data after raw value: %d bytes", len(data)-consumed)
+	}
+
+	fullBytes := make([]byte, consumed)
+	copy(fullBytes, data)
+	return RawValue{
+		Class:      value.tag.Class,
+		Tag:        value.tag.Number,
+		IsCompound: value.tag.Constructed,
+		Bytes:      value.value,
+		FullBytes:  fullBytes,
+	}, nil
+}
+
+// Unmarshal decodes the raw value into v, as Unmarshal would
+func (r *RawValue) Unmarshal(v interface{}) error {
+	encoded, err := r.Encode()
+	if err != nil {
+		return err
+	}
+	return Unmarshal(encoded, v)
+}
+
+// Object decodes the raw value into the library's typed ASN.1 object
+func (r *RawValue) Object() (ASN1Object, error) {
+	encoded, err := r.Encode()
+	if err != nil {
+		return nil, err
+	}
+	value, _, err := DecodeTLV(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return convertToHighLevelObject(value), nil
+}
+
+// TagInfo returns the tag of the raw value
+func (r *RawValue) TagInfo() Tag {
+	return NewTag(r.Class, r.IsCompound, r.Tag)
+}
+
+// Encode returns FullBytes if set, or the TLV built from the other fields
+func (r *RawValue) Encode() ([]byte, error) {
+	if len(r.FullBytes) > 0 {
+		result := make([]byte, len(r.FullBytes))
+		copy(result, r.FullBytes)
+		return result, nil
+	}
+	return EncodeTLV(r.TagInfo(), r.Bytes)
+}
+
+// String returns a string representation of the raw value
+func (r *RawValue) String() string {
+	return fmt.Sprintf("RawValue{tag: %v, value: %x}", r.TagInfo(), r.Bytes)
+}
+
+// TaggedString returns a string representation with tag information
+func (r *RawValue) TaggedString() string {
+	return fmt.Sprintf("%s RawValue: %x", r.TagInfo().TagString(), r.Bytes)
+}
+
+// OpenTypes maps object identifiers to the Go types of the values they
+// identify, so that a RawValue can be decoded once its sibling OBJECT
+// IDENTIFIER field is known. It is safe for concurrent use.
+type OpenTypes struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+}
+
+// NewOpenTypes creates an empty OpenTypes registry
+func NewOpenTypes() *OpenTypes {
+	return &OpenTypes{types: make(map[string]reflect.Type)}
+}
+
+// Register associates a dot-separated OID with the type of prototype.
+// prototype may be a value or a pointer; either way values decode into a new
+// instance of the underlying type.
+func (t *OpenTypes) Register(oid string, prototype interface{}) error {
+	parsed, err := NewObjectIdentifierFromString(oid)
+	if err != nil {
+		return fmt.Errorf("invalid OID %q: %w", oid, err)
+	}
+	if prototype == nil {
+		return fmt.Errorf("prototype for OID %s cannot be nil", oid)
+	}
+
+	typ := reflect.TypeOf(prototype)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.types[parsed.DotNotation()] = typ
+	return nil
+}
+
+// Lookup returns the type registered for an OID
+func (t *OpenTypes) Lookup(oid *ASN1ObjectIdentifier) (reflect.Type, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	typ, ok := t.types[oid.DotNotation()]
+	return typ, ok
+}
+
+// Decode decodes raw into a new value of the type registered for oid and
+// returns a pointer to it
+func (t *OpenTypes) Decode(oid *ASN1ObjectIdentifier, raw RawValue) (interface{}, error) {
+	if oid == nil {
+		return nil, fmt.Errorf("open type identifier is nil")
+	}
+	typ, ok := t.Lookup(oid)
+	if !ok {
+		return nil, fmt.Errorf("no type registered for %s", oid.DotNotation())
+	}
+
+	value := reflect.New(typ)
+	if err := raw.Unmarshal(value.Interface()); err != nil {
+		return nil, fmt.Errorf("open type %s: %w", oid.DotNotation(), err)
+	}
+	return value.Interface(), nil
+}
+
+// DecodeString is like Decode but takes the identifier in dot notation,
+// as held by fields tagged objectidentifier
+func (t *OpenTypes) DecodeString(oid string, raw RawValue) (interface{}, error) {
+	parsed, err := NewObjectIdentifierFromString(oid)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OID %q: %w", oid, err)
+	}
+	return t.Decode(parsed, raw)
+}
+
+var (
+	rawValueType  = reflect.TypeOf(RawValue{})
+	asn1ValueType = reflect.TypeOf(ASN1Value{})
+)
+
+// unmarshalRaw fills RawValue and ASN1Value targets with the encoding of obj
+func unmarshalRaw(obj ASN1Object, v reflect.Value, opts *MarshalOptions) error {
+	encoded, err := unmarshaledEncoding(obj, opts)
+	if err != nil {
+		return fmt.Errorf("failed to capture raw value: %w", err)
+	}
+	raw, err := ParseRawValue(encoded)
+	if err != nil {
+		return fmt.Errorf("failed to capture raw value: %w", err)
+	}
+	if v.Type() == rawValueType {
+		v.Set(reflect.ValueOf(raw))
+	} else {
+		v.Set(reflect.ValueOf(*NewASN1Value(raw.TagInfo(), raw.Bytes)))
+	}
+	return nil
+}
+
+// rawObject adapts a RawValue to ASN1Object for marshaling; RawValue itself
+// cannot implement the interface because its Tag field shadows Tag()
+type rawObject struct {
+	raw *RawValue
+}
+
+func (o *rawObject) Encode() ([]byte, error) { return o.raw.Encode() }
+func (o *rawObject) String() string          { return o.raw.String() }
+func (o *rawObject) Tag() Tag                { return o.raw.TagInfo() }
+func (o *rawObject) TaggedString() string    { return o.raw.TaggedString() }
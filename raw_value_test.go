@@ -0,0 +1,183 @@
+package asn1
+
+import (
+	"bytes"
+	"testing"
+)
+
+type algorithmIdentifier struct {
+	Algorithm  string   `asn1:"objectidentifier"`
+	Parameters RawValue `asn1:"any"`
+}
+
+type rsaPSSParams struct {
+	HashAlgorithm string `asn1:"objectidentifier"`
+	SaltLength    int64  `asn1:"integer"`
+}
+
+func TestRawValueOpenType(t *testing.T) {
+	params, err := Marshal(rsaPSSParams{HashAlgorithm: "2.16.840.1.101.3.4.2.1", SaltLength: 32})
+	if err != nil {
+		t.Fatalf("Marshal() params error = %v", err)
+	}
+	raw, err := ParseRawValue(params)
+	if err != nil {
+		t.Fatalf("ParseRawValue() error = %v", err)
+	}
+
+	encoded, err := Marshal(algorithmIdentifier{Algorithm: "1.2.840.113549.1.1.10", Parameters: raw})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded algorithmIdentifier
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Parameters.Class != 0 || decoded.Parameters.Tag != TagSequence || !decoded.Parameters.IsCompound {
+		t.Errorf("Parameters = %+v", decoded.Parameters)
+	}
+	if !bytes.Equal(decoded.Parameters.FullBytes, params) {
+		t.Errorf("FullBytes = %x, want %x", decoded.Parameters.FullBytes, params)
+	}
+
+	// The sibling OID selects the concrete type
+	types := NewOpenTypes()
+	if err := types.Register("1.2.840.113549.1.1.10", rsaPSSParams{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	value, err := types.DecodeString(decoded.Algorithm, decoded.Parameters)
+	if err != nil {
+		t.Fatalf("DecodeString() error = %v", err)
+	}
+	pss, ok := value.(*rsaPSSParams)
+	if !ok || pss.SaltLength != 32 || pss.HashAlgorithm != "2.16.840.1.101.3.4.2.1" {
+		t.Errorf("DecodeString() = %#v", value)
+	}
+
+	if _, err := types.DecodeString("1.2.3", decoded.Parameters); err == nil {
+		t.Errorf("expected error for unregistered OID")
+	}
+	if err := types.Register("not.an.oid", rsaPSSParams{}); err == nil {
+		t.Errorf("expected error for invalid OID")
+	}
+}
+
+func TestRawValueKeepsContextTag(t *testing.T) {
+	type message struct {
+		ID   int64    `asn1:"integer"`
+		Body RawValue `asn1:"any,tag:1"`
+	}
+
+	body := RawValue{Class: 2, Tag: 1, Bytes: []byte{0x0A, 0x0B}}
+	encoded, err := Marshal(message{ID: 7, Body: body})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x07, 0x02, 0x01, 0x07, 0x81, 0x02, 0x0A, 0x0B}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+
+	var decoded message
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Body.Class != 2 || decoded.Body.Tag != 1 || decoded.Body.IsCompound || !bytes.Equal(decoded.Body.Bytes, []byte{0x0A, 0x0B}) {
+		t.Errorf("Body = %+v", decoded.Body)
+	}
+	if !bytes.Equal(decoded.Body.FullBytes, want[5:]) {
+		t.Errorf("FullBytes = %x, want %x", decoded.Body.FullBytes, want[5:])
+	}
+}
+
+func TestUnmarshalIntoASN1Value(t *testing.T) {
+	var value ASN1Value
+	if err := Unmarshal([]byte{0x02, 0x01, 0x2A}, &value); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if value.Tag().Number != TagInteger || !bytes.Equal(value.Value(), []byte{0x2A}) {
+		t.Errorf("Unmarshal() = %s", value.String())
+	}
+
+	var raw RawValue
+	if err := Unmarshal([]byte{0x04, 0x01, 0xFF}, &raw); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	obj, err := raw.Object()
+	if err != nil {
+		t.Fatalf("Object() error = %v", err)
+	}
+	if octets, ok := obj.(*ASN1OctetString); !ok || !bytes.Equal(octets.Value(), []byte{0xFF}) {
+		t.Errorf("Object() = %v", obj)
+	}
+}
+
+func TestRawValueKeepsBERBytes(t *testing.T) {
+	// BOOLEAN true as 0x01, which DER would write as 0xFF
+	var raw RawValue
+	if err := Unmarshal([]byte{0x01, 0x01, 0x01}, &raw); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !bytes.Equal(raw.FullBytes, []byte{0x01, 0x01, 0x01}) {
+		t.Errorf("FullBytes = %x, want 010101", raw.FullBytes)
+	}
+
+	data := []byte{0x30, 0x08, 0x06, 0x03, 0x2A, 0x03, 0x04, 0x01, 0x01, 0x01}
+	var algorithm algorithmIdentifier
+	if err := Unmarshal(data, &algorithm); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !bytes.Equal(algorithm.Parameters.FullBytes, data[7:]) {
+		t.Errorf("Parameters.FullBytes = %x, want %x", algorithm.Parameters.FullBytes, data[7:])
+	}
+}
+
+func TestInstanceOf(t *testing.T) {
+	typeID := NewObjectIdentifierFromStringUnchecked("2.5.4.3")
+	instance := NewInstanceOf(typeID, NewUTF8String("example"))
+	encoded, err := instance.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	want := []byte{0x28, 0x10, 0x06, 0x03, 0x55, 0x04, 0x03, 0xA0, 0x09}
+	if !bytes.HasPrefix(encoded, want) {
+		t.Errorf("Encode() = %x, want prefix %x", encoded, want)
+	}
+
+	decoded, _, err := DecodeExternal(encoded)
+	if err != nil {
+		t.Fatalf("DecodeExternal() error = %v", err)
+	}
+	if !decoded.DirectReference().Equal(typeID) || decoded.Value().(*ASN1UTF8String).Value() != "example" {
+		t.Errorf("DecodeExternal() = %s", decoded)
+	}
+}
+
+func TestObjectDescriptor(t *testing.T) {
+	descriptor := NewObjectDescriptor("ACSE PDU")
+	encoded, err := descriptor.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if encoded[0] != 0x07 {
+		t.Errorf("tag = %#x, want 0x07", encoded[0])
+	}
+
+	decoded, _, err := DecodeObjectDescriptor(encoded)
+	if err != nil || decoded.Value() != "ACSE PDU" {
+		t.Errorf("DecodeObjectDescriptor() = %v, %v", decoded, err)
+	}
+
+	type labeled struct {
+		Name string `asn1:"objectdescriptor"`
+	}
+	data, err := Marshal(labeled{Name: "ACSE PDU"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var back labeled
+	if err := Unmarshal(data, &back); err != nil || back.Name != "ACSE PDU" {
+		t.Errorf("Unmarshal() = %+v, %v", back, err)
+	}
+}
@@ -0,0 +1,167 @@
+package asn1
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRelativeOIDEncoding(t *testing.T) {
+	rel, err := NewRelativeOID([]int{8571, 3, 2})
+	if err != nil {
+		t.Fatalf("NewRelativeOID() error = %v", err)
+	}
+	encoded, err := rel.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	// X.690 section 8.20.5 example
+	want := []byte{0x0D, 0x04, 0xC2, 0x7B, 0x03, 0x02}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Encode() = %x, want %x", encoded, want)
+	}
+
+	decoded, consumed, err := DecodeRelativeOID(encoded)
+	if err != nil {
+		t.Fatalf("DecodeRelativeOID() error = %v", err)
+	}
+	if consumed != len(encoded) || decoded.DotNotation() != "8571.3.2" {
+		t.Errorf("DecodeRelativeOID() = %s (%d bytes)", decoded.DotNotation(), consumed)
+	}
+
+	if _, err := NewRelativeOIDFromString("1..2"); err == nil {
+		t.Error("expected error for empty component")
+	}
+	if _, _, err := DecodeRelativeOID([]byte{0x0D, 0x01, 0x81}); err == nil {
+		t.Error("expected error for truncated subidentifier")
+	}
+}
+
+func TestRelativeOIDJoin(t *testing.T) {
+	base := NewObjectIdentifierFromStringUnchecked("1.3.6.1.4.1")
+	rel, _ := NewRelativeOIDFromString("311.21.20")
+
+	joined := base.Join(rel)
+	if joined.DotNotation() != "1.3.6.1.4.1.311.21.20" {
+		t.Errorf("Join() = %s", joined.DotNotation())
+	}
+
+	back, err := joined.RelativeTo(base)
+	if err != nil {
+		t.Fatalf("RelativeTo() error = %v", err)
+	}
+	if back.DotNotation() != rel.DotNotation() {
+		t.Errorf("RelativeTo() = %s, want %s", back.DotNotation(), rel.DotNotation())
+	}
+
+	if _, err := base.RelativeTo(joined); err == nil {
+		t.Error("expected error when base is longer than the OID")
+	}
+	if _, err := joined.RelativeTo(NewObjectIdentifierFromStringUnchecked("1.3.6.1.5")); err == nil {
+		t.Error("expected error when base is not a prefix")
+	}
+}
+
+func TestOIDIRI(t *testing.T) {
+	iri, err := NewOIDIRI("/ISO/Registration_Authority/19785.CBEFF")
+	if err != nil {
+		t.Fatalf("NewOIDIRI() error = %v", err)
+	}
+	encoded, err := iri.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	// Tag 35 needs the high-tag-number form
+	if !bytes.Equal(encoded[:3], []byte{0x1F, 0x23, byte(len(iri.Value()))}) || string(encoded[3:]) != iri.Value() {
+		t.Errorf("Encode() = %x", encoded)
+	}
+	decoded, _, err := DecodeOIDIRI(encoded)
+	if err != nil || decoded.Value() != iri.Value() {
+		t.Errorf("DecodeOIDIRI() = %v, %v", decoded, err)
+	}
+	if _, err := iri.ObjectIdentifier(); err == nil {
+		t.Error("expected error converting non-integer labels")
+	}
+
+	numeric := NewOIDIRIFromObjectIdentifier(NewObjectIdentifierFromStringUnchecked("2.999.1"))
+	rel, err := NewRelativeOIDIRI("3/Ärger")
+	if err != nil {
+		t.Fatalf("NewRelativeOIDIRI() error = %v", err)
+	}
+	if got := numeric.Join(rel).Value(); got != "/2/999/1/3/Ärger" {
+		t.Errorf("Join() = %q", got)
+	}
+
+	oid, err := numeric.ObjectIdentifier()
+	if err != nil || oid.DotNotation() != "2.999.1" {
+		t.Errorf("ObjectIdentifier() = %v, %v", oid, err)
+	}
+	rel45, _ := NewRelativeOID([]int{4, 5})
+	relOID, err := NewRelativeOIDIRIFromRelativeOID(rel45).RelativeOID()
+	if err != nil || relOID.DotNotation() != "4.5" {
+		t.Errorf("RelativeOID() = %v, %v", relOID, err)
+	}
+
+	for _, input := range []string{"", "ISO", "/", "/ISO//A", "/01", "/a b", "/a?"} {
+		if _, err := NewOIDIRI(input); err == nil {
+			t.Errorf("NewOIDIRI(%q) expected error", input)
+		}
+	}
+	if _, err := NewRelativeOIDIRI("/ISO"); err == nil {
+		t.Error("expected error for relative IRI with leading slash")
+	}
+	if _, _, err := DecodeRelativeOIDIRI([]byte{0x1F, 0x24, 0x02, 'a', '/'}); err == nil {
+		t.Error("expected error for trailing slash")
+	}
+}
+
+type ObjectReference struct {
+	Base     string  `asn1:"objectidentifier"`
+	Arcs     []int   `asn1:"relativeoid"`
+	Suffix   string  `asn1:"relativeoid"`
+	IRI      string  `asn1:"oidiri"`
+	Relative string  `asn1:"relativeoidiri"`
+	Tagged   *string `asn1:"relativeoid,optional,tag:0"`
+}
+
+func TestObjectIdentifierMarshaling(t *testing.T) {
+	tagged := "7.8"
+	original := &ObjectReference{
+		Base:     "1.2.840.113549",
+		Arcs:     []int{1, 1, 11},
+		Suffix:   "2.1",
+		IRI:      "/ISO/Member-Body/840",
+		Relative: "Ärger/7",
+		Tagged:   &tagged,
+	}
+
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded ObjectReference
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Base != original.Base || decoded.Suffix != original.Suffix ||
+		decoded.IRI != original.IRI || decoded.Relative != original.Relative {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", decoded, *original)
+	}
+	if len(decoded.Arcs) != 3 || decoded.Arcs[2] != 11 {
+		t.Errorf("Arcs = %v, want %v", decoded.Arcs, original.Arcs)
+	}
+	if decoded.Tagged == nil || *decoded.Tagged != tagged {
+		t.Errorf("Tagged = %v, want %q", decoded.Tagged, tagged)
+	}
+
+	bad := *original
+	bad.IRI = "ISO"
+	if _, err := Marshal(&bad); err == nil {
+		t.Error("expected error for IRI without leading slash")
+	}
+	bad = *original
+	bad.Base = "3.1"
+	if _, err := Marshal(&bad); err == nil {
+		t.Error("expected error for invalid object identifier")
+	}
+}
@@ -0,0 +1,76 @@
+package asn1
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type strictRecord struct {
+	ID int64 `asn1:"integer"`
+}
+
+func TestRejectTrailingData(t *testing.T) {
+	data := []byte{0x30, 0x03, 0x02, 0x01, 0x01, 0x00}
+
+	var record strictRecord
+	if err := Unmarshal(data, &record); err != nil || record.ID != 1 {
+		t.Fatalf("Unmarshal() = %+v, %v", record, err)
+	}
+
+	opts := DefaultMarshalOptions()
+	opts.RejectTrailingData = true
+	err := UnmarshalWithOptions(data, &record, opts)
+	if err == nil || !strings.Contains(err.Error(), "trailing data") {
+		t.Errorf("UnmarshalWithOptions() error = %v, want trailing data error", err)
+	}
+	if err := UnmarshalWithOptions(data[:5], &record, opts); err != nil {
+		t.Errorf("UnmarshalWithOptions() error = %v", err)
+	}
+}
+
+func TestRejectUnknownElements(t *testing.T) {
+	data := []byte{0x30, 0x06, 0x02, 0x01, 0x01, 0x01, 0x01, 0xFF}
+
+	var record strictRecord
+	if err := Unmarshal(data, &record); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	opts := DefaultMarshalOptions()
+	opts.RejectUnknownElements = true
+	err := UnmarshalWithOptions(data, &record, opts)
+	if err == nil || !strings.Contains(err.Error(), "unexpected elements") {
+		t.Errorf("UnmarshalWithOptions() error = %v, want unexpected elements error", err)
+	}
+
+	// An extensions field accepts the leftovers
+	var extensible callInfoV1
+	if err := UnmarshalWithOptions(data, &extensible, opts); err != nil || len(extensible.Extensions) != 1 {
+		t.Errorf("UnmarshalWithOptions() = %+v, %v", extensible, err)
+	}
+}
+
+func TestUnmarshalRest(t *testing.T) {
+	data := []byte{0x30, 0x03, 0x02, 0x01, 0x01, 0x30, 0x03, 0x02, 0x01, 0x02}
+
+	var ids []int64
+	for len(data) > 0 {
+		var record strictRecord
+		rest, err := UnmarshalRest(data, &record)
+		if err != nil {
+			t.Fatalf("UnmarshalRest() error = %v", err)
+		}
+		ids = append(ids, record.ID)
+		data = rest
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("UnmarshalRest() ids = %v", ids)
+	}
+
+	var record strictRecord
+	rest, err := UnmarshalRest([]byte{0x30, 0x03, 0x02, 0x01, 0x05, 0xAA}, &record)
+	if err != nil || !bytes.Equal(rest, []byte{0xAA}) || record.ID != 5 {
+		t.Errorf("UnmarshalRest() = %x, %+v, %v", rest, record, err)
+	}
+}
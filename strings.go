@@ -2,6 +2,7 @@ package asn1
 
 import (
 	"fmt"
+	"unicode/utf16"
 	"unicode/utf8"
 )
 
@@ -10,12 +11,24 @@ type ASN1UTF8String struct {
 	value string
 }
 
-// NewUTF8String creates a new ASN1UTF8String
-func NewUTF8String(value string) *ASN1UTF8String {
+// NewUTF8StringChecked creates a new ASN1UTF8String, reporting an error if value
+// contains characters the type cannot hold
+func NewUTF8StringChecked(value string) (*ASN1UTF8String, error) {
 	if !utf8.ValidString(value) {
-		panic("invalid UTF-8 string")
+		return nil, fmt.Errorf("invalid UTF-8 string")
+	}
+	return &ASN1UTF8String{value: value}, nil
+}
+
+// NewUTF8String creates a new ASN1UTF8String.
+// It panics if value contains characters the type cannot hold; use
+// NewUTF8StringChecked for values that are not known to be valid.
+func NewUTF8String(value string) *ASN1UTF8String {
+	s, err := NewUTF8StringChecked(value)
+	if err != nil {
+		panic(err.Error())
 	}
-	return &ASN1UTF8String{value: value}
+	return s
 }
 
 // Value returns the string value
@@ -48,12 +61,24 @@ type ASN1PrintableString struct {
 	value string
 }
 
-// NewPrintableString creates a new ASN1PrintableString
-func NewPrintableString(value string) *ASN1PrintableString {
+// NewPrintableStringChecked creates a new ASN1PrintableString, reporting an error if value
+// contains characters the type cannot hold
+func NewPrintableStringChecked(value string) (*ASN1PrintableString, error) {
 	if !isPrintableString(value) {
-		panic("string contains non-printable characters")
+		return nil, fmt.Errorf("string contains non-printable characters")
 	}
-	return &ASN1PrintableString{value: value}
+	return &ASN1PrintableString{value: value}, nil
+}
+
+// NewPrintableString creates a new ASN1PrintableString.
+// It panics if value contains characters the type cannot hold; use
+// NewPrintableStringChecked for values that are not known to be valid.
+func NewPrintableString(value string) *ASN1PrintableString {
+	s, err := NewPrintableStringChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
 }
 
 // Value returns the string value
@@ -86,12 +111,24 @@ type ASN1IA5String struct {
 	value string
 }
 
-// NewIA5String creates a new ASN1IA5String
-func NewIA5String(value string) *ASN1IA5String {
+// NewIA5StringChecked creates a new ASN1IA5String, reporting an error if value
+// contains characters the type cannot hold
+func NewIA5StringChecked(value string) (*ASN1IA5String, error) {
 	if !isIA5String(value) {
-		panic("string contains non-IA5 characters")
+		return nil, fmt.Errorf("string contains non-IA5 characters")
+	}
+	return &ASN1IA5String{value: value}, nil
+}
+
+// NewIA5String creates a new ASN1IA5String.
+// It panics if value contains characters the type cannot hold; use
+// NewIA5StringChecked for values that are not known to be valid.
+func NewIA5String(value string) *ASN1IA5String {
+	s, err := NewIA5StringChecked(value)
+	if err != nil {
+		panic(err.Error())
 	}
-	return &ASN1IA5String{value: value}
+	return s
 }
 
 // Value returns the string value
@@ -119,6 +156,457 @@ func (s *ASN1IA5String) TaggedString() string {
 	return fmt.Sprintf("%s IA5String: \"%s\"", s.Tag().TagString(), s.value)
 }
 
+// ASN1NumericString represents an ASN.1 NumericString (digits and space)
+type ASN1NumericString struct {
+	value string
+}
+
+// NewNumericStringChecked creates a new ASN1NumericString, reporting an error if value
+// contains characters the type cannot hold
+func NewNumericStringChecked(value string) (*ASN1NumericString, error) {
+	if !isNumericString(value) {
+		return nil, fmt.Errorf("string contains non-numeric characters")
+	}
+	return &ASN1NumericString{value: value}, nil
+}
+
+// NewNumericString creates a new ASN1NumericString.
+// It panics if value contains characters the type cannot hold; use
+// NewNumericStringChecked for values that are not known to be valid.
+func NewNumericString(value string) *ASN1NumericString {
+	s, err := NewNumericStringChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Value returns the string value
+func (s *ASN1NumericString) Value() string {
+	return s.value
+}
+
+// Tag returns the ASN.1 tag for NumericString
+func (s *ASN1NumericString) Tag() Tag {
+	return NewUniversalTag(TagNumericString, false)
+}
+
+// Encode returns the BER encoding of the numeric string
+func (s *ASN1NumericString) Encode() ([]byte, error) {
+	return EncodeTLV(s.Tag(), []byte(s.value))
+}
+
+// String returns a string representation
+func (s *ASN1NumericString) String() string {
+	return fmt.Sprintf("NumericString \"%s\"", s.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (s *ASN1NumericString) TaggedString() string {
+	return fmt.Sprintf("%s NumericString: \"%s\"", s.Tag().TagString(), s.value)
+}
+
+// ASN1VisibleString represents an ASN.1 VisibleString (ISO646String, printable ASCII)
+type ASN1VisibleString struct {
+	value string
+}
+
+// NewVisibleStringChecked creates a new ASN1VisibleString, reporting an error if value
+// contains characters the type cannot hold
+func NewVisibleStringChecked(value string) (*ASN1VisibleString, error) {
+	if !isVisibleString(value) {
+		return nil, fmt.Errorf("string contains non-visible characters")
+	}
+	return &ASN1VisibleString{value: value}, nil
+}
+
+// NewVisibleString creates a new ASN1VisibleString.
+// It panics if value contains characters the type cannot hold; use
+// NewVisibleStringChecked for values that are not known to be valid.
+func NewVisibleString(value string) *ASN1VisibleString {
+	s, err := NewVisibleStringChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Value returns the string value
+func (s *ASN1VisibleString) Value() string {
+	return s.value
+}
+
+// Tag returns the ASN.1 tag for VisibleString
+func (s *ASN1VisibleString) Tag() Tag {
+	return NewUniversalTag(TagVisibleString, false)
+}
+
+// Encode returns the BER encoding of the visible string
+func (s *ASN1VisibleString) Encode() ([]byte, error) {
+	return EncodeTLV(s.Tag(), []byte(s.value))
+}
+
+// String returns a string representation
+func (s *ASN1VisibleString) String() string {
+	return fmt.Sprintf("VisibleString \"%s\"", s.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (s *ASN1VisibleString) TaggedString() string {
+	return fmt.Sprintf("%s VisibleString: \"%s\"", s.Tag().TagString(), s.value)
+}
+
+// ASN1TeletexString represents an ASN.1 TeletexString (T61String)
+type ASN1TeletexString struct {
+	value string
+}
+
+// NewTeletexStringChecked creates a new ASN1TeletexString, reporting an error if value
+// contains characters the type cannot hold
+func NewTeletexStringChecked(value string) (*ASN1TeletexString, error) {
+	if !isLatin1String(value) {
+		return nil, fmt.Errorf("string contains characters outside the 8-bit range")
+	}
+	return &ASN1TeletexString{value: value}, nil
+}
+
+// NewTeletexString creates a new ASN1TeletexString.
+// It panics if value contains characters the type cannot hold; use
+// NewTeletexStringChecked for values that are not known to be valid.
+func NewTeletexString(value string) *ASN1TeletexString {
+	s, err := NewTeletexStringChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Value returns the string value
+func (s *ASN1TeletexString) Value() string {
+	return s.value
+}
+
+// Tag returns the ASN.1 tag for TeletexString
+func (s *ASN1TeletexString) Tag() Tag {
+	return NewUniversalTag(TagTeletexString, false)
+}
+
+// Encode returns the BER encoding of the teletex string
+func (s *ASN1TeletexString) Encode() ([]byte, error) {
+	return EncodeTLV(s.Tag(), encodeLatin1(s.value))
+}
+
+// String returns a string representation
+func (s *ASN1TeletexString) String() string {
+	return fmt.Sprintf("TeletexString \"%s\"", s.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (s *ASN1TeletexString) TaggedString() string {
+	return fmt.Sprintf("%s TeletexString: \"%s\"", s.Tag().TagString(), s.value)
+}
+
+// ASN1VideotexString represents an ASN.1 VideotexString
+type ASN1VideotexString struct {
+	value string
+}
+
+// NewVideotexStringChecked creates a new ASN1VideotexString, reporting an error if value
+// contains characters the type cannot hold
+func NewVideotexStringChecked(value string) (*ASN1VideotexString, error) {
+	if !isLatin1String(value) {
+		return nil, fmt.Errorf("string contains characters outside the 8-bit range")
+	}
+	return &ASN1VideotexString{value: value}, nil
+}
+
+// NewVideotexString creates a new ASN1VideotexString.
+// It panics if value contains characters the type cannot hold; use
+// NewVideotexStringChecked for values that are not known to be valid.
+func NewVideotexString(value string) *ASN1VideotexString {
+	s, err := NewVideotexStringChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Value returns the string value
+func (s *ASN1VideotexString) Value() string {
+	return s.value
+}
+
+// Tag returns the ASN.1 tag for VideotexString
+func (s *ASN1VideotexString) Tag() Tag {
+	return NewUniversalTag(TagVideotexString, false)
+}
+
+// Encode returns the BER encoding of the videotex string
+func (s *ASN1VideotexString) Encode() ([]byte, error) {
+	return EncodeTLV(s.Tag(), encodeLatin1(s.value))
+}
+
+// String returns a string representation
+func (s *ASN1VideotexString) String() string {
+	return fmt.Sprintf("VideotexString \"%s\"", s.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (s *ASN1VideotexString) TaggedString() string {
+	return fmt.Sprintf("%s VideotexString: \"%s\"", s.Tag().TagString(), s.value)
+}
+
+// ASN1GraphicString represents an ASN.1 GraphicString (graphic characters and space)
+type ASN1GraphicString struct {
+	value string
+}
+
+// NewGraphicStringChecked creates a new ASN1GraphicString, reporting an error if value
+// contains characters the type cannot hold
+func NewGraphicStringChecked(value string) (*ASN1GraphicString, error) {
+	if !isGraphicString(value) {
+		return nil, fmt.Errorf("string contains non-graphic characters")
+	}
+	return &ASN1GraphicString{value: value}, nil
+}
+
+// NewGraphicString creates a new ASN1GraphicString.
+// It panics if value contains characters the type cannot hold; use
+// NewGraphicStringChecked for values that are not known to be valid.
+func NewGraphicString(value string) *ASN1GraphicString {
+	s, err := NewGraphicStringChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Value returns the string value
+func (s *ASN1GraphicString) Value() string {
+	return s.value
+}
+
+// Tag returns the ASN.1 tag for GraphicString
+func (s *ASN1GraphicString) Tag() Tag {
+	return NewUniversalTag(TagGraphicString, false)
+}
+
+// Encode returns the BER encoding of the graphic string
+func (s *ASN1GraphicString) Encode() ([]byte, error) {
+	return EncodeTLV(s.Tag(), encodeLatin1(s.value))
+}
+
+// String returns a string representation
+func (s *ASN1GraphicString) String() string {
+	return fmt.Sprintf("GraphicString \"%s\"", s.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (s *ASN1GraphicString) TaggedString() string {
+	return fmt.Sprintf("%s GraphicString: \"%s\"", s.Tag().TagString(), s.value)
+}
+
+// ASN1GeneralString represents an ASN.1 GeneralString
+type ASN1GeneralString struct {
+	value string
+}
+
+// NewGeneralStringChecked creates a new ASN1GeneralString, reporting an error if value
+// contains characters the type cannot hold
+func NewGeneralStringChecked(value string) (*ASN1GeneralString, error) {
+	if !isLatin1String(value) {
+		return nil, fmt.Errorf("string contains characters outside the 8-bit range")
+	}
+	return &ASN1GeneralString{value: value}, nil
+}
+
+// NewGeneralString creates a new ASN1GeneralString.
+// It panics if value contains characters the type cannot hold; use
+// NewGeneralStringChecked for values that are not known to be valid.
+func NewGeneralString(value string) *ASN1GeneralString {
+	s, err := NewGeneralStringChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Value returns the string value
+func (s *ASN1GeneralString) Value() string {
+	return s.value
+}
+
+// Tag returns the ASN.1 tag for GeneralString
+func (s *ASN1GeneralString) Tag() Tag {
+	return NewUniversalTag(TagGeneralString, false)
+}
+
+// Encode returns the BER encoding of the general string
+func (s *ASN1GeneralString) Encode() ([]byte, error) {
+	return EncodeTLV(s.Tag(), encodeLatin1(s.value))
+}
+
+// String returns a string representation
+func (s *ASN1GeneralString) String() string {
+	return fmt.Sprintf("GeneralString \"%s\"", s.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (s *ASN1GeneralString) TaggedString() string {
+	return fmt.Sprintf("%s GeneralString: \"%s\"", s.Tag().TagString(), s.value)
+}
+
+// ASN1BMPString represents an ASN.1 BMPString (UCS-2, big-endian)
+type ASN1BMPString struct {
+	value string
+}
+
+// NewBMPStringChecked creates a new ASN1BMPString, reporting an error if value
+// contains characters the type cannot hold
+func NewBMPStringChecked(value string) (*ASN1BMPString, error) {
+	if !isBMPString(value) {
+		return nil, fmt.Errorf("string contains characters outside the Basic Multilingual Plane")
+	}
+	return &ASN1BMPString{value: value}, nil
+}
+
+// NewBMPString creates a new ASN1BMPString.
+// It panics if value contains characters the type cannot hold; use
+// NewBMPStringChecked for values that are not known to be valid.
+func NewBMPString(value string) *ASN1BMPString {
+	s, err := NewBMPStringChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Value returns the string value
+func (s *ASN1BMPString) Value() string {
+	return s.value
+}
+
+// Tag returns the ASN.1 tag for BMPString
+func (s *ASN1BMPString) Tag() Tag {
+	return NewUniversalTag(TagBMPString, false)
+}
+
+// Encode returns the BER encoding of the BMP string
+func (s *ASN1BMPString) Encode() ([]byte, error) {
+	return EncodeTLV(s.Tag(), encodeBMPString(s.value))
+}
+
+// String returns a string representation
+func (s *ASN1BMPString) String() string {
+	return fmt.Sprintf("BMPString \"%s\"", s.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (s *ASN1BMPString) TaggedString() string {
+	return fmt.Sprintf("%s BMPString: \"%s\"", s.Tag().TagString(), s.value)
+}
+
+// ASN1UniversalString represents an ASN.1 UniversalString (UCS-4, big-endian)
+type ASN1UniversalString struct {
+	value string
+}
+
+// NewUniversalStringChecked creates a new ASN1UniversalString, reporting an error if value
+// contains characters the type cannot hold
+func NewUniversalStringChecked(value string) (*ASN1UniversalString, error) {
+	if !utf8.ValidString(value) {
+		return nil, fmt.Errorf("invalid UTF-8 string")
+	}
+	return &ASN1UniversalString{value: value}, nil
+}
+
+// NewUniversalString creates a new ASN1UniversalString.
+// It panics if value contains characters the type cannot hold; use
+// NewUniversalStringChecked for values that are not known to be valid.
+func NewUniversalString(value string) *ASN1UniversalString {
+	s, err := NewUniversalStringChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Value returns the string value
+func (s *ASN1UniversalString) Value() string {
+	return s.value
+}
+
+// Tag returns the ASN.1 tag for UniversalString
+func (s *ASN1UniversalString) Tag() Tag {
+	return NewUniversalTag(TagUniversalString, false)
+}
+
+// Encode returns the BER encoding of the universal string
+func (s *ASN1UniversalString) Encode() ([]byte, error) {
+	return EncodeTLV(s.Tag(), encodeUniversalString(s.value))
+}
+
+// String returns a string representation
+func (s *ASN1UniversalString) String() string {
+	return fmt.Sprintf("UniversalString \"%s\"", s.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (s *ASN1UniversalString) TaggedString() string {
+	return fmt.Sprintf("%s UniversalString: \"%s\"", s.Tag().TagString(), s.value)
+}
+
+// ASN1ObjectDescriptor represents an ASN.1 ObjectDescriptor, a human-readable
+// description of an object encoded as [UNIVERSAL 7] IMPLICIT GraphicString
+type ASN1ObjectDescriptor struct {
+	value string
+}
+
+// NewObjectDescriptorChecked creates a new ASN1ObjectDescriptor, reporting an error if value
+// contains characters the type cannot hold
+func NewObjectDescriptorChecked(value string) (*ASN1ObjectDescriptor, error) {
+	if !isGraphicString(value) {
+		return nil, fmt.Errorf("string contains non-graphic characters")
+	}
+	return &ASN1ObjectDescriptor{value: value}, nil
+}
+
+// NewObjectDescriptor creates a new ASN1ObjectDescriptor.
+// It panics if value contains characters the type cannot hold; use
+// NewObjectDescriptorChecked for values that are not known to be valid.
+func NewObjectDescriptor(value string) *ASN1ObjectDescriptor {
+	s, err := NewObjectDescriptorChecked(value)
+	if err != nil {
+		panic(err.Error())
+	}
+	return s
+}
+
+// Value returns the string value
+func (s *ASN1ObjectDescriptor) Value() string {
+	return s.value
+}
+
+// Tag returns the ASN.1 tag for ObjectDescriptor
+func (s *ASN1ObjectDescriptor) Tag() Tag {
+	return NewUniversalTag(TagObjectDescriptor, false)
+}
+
+// Encode returns the BER encoding of the object descriptor
+func (s *ASN1ObjectDescriptor) Encode() ([]byte, error) {
+	return EncodeTLV(s.Tag(), encodeLatin1(s.value))
+}
+
+// String returns a string representation
+func (s *ASN1ObjectDescriptor) String() string {
+	return fmt.Sprintf("ObjectDescriptor \"%s\"", s.value)
+}
+
+// TaggedString returns a string representation with tag information
+func (s *ASN1ObjectDescriptor) TaggedString() string {
+	return fmt.Sprintf("%s ObjectDescriptor: \"%s\"", s.Tag().TagString(), s.value)
+}
+
 // Helper functions for string validation
 
 // isPrintableString checks if a string contains only PrintableString characters
@@ -154,6 +642,291 @@ func isIA5String(s string) bool {
 	return true
 }
 
+// isNumericString checks if a string contains only NumericString characters
+func isNumericString(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && r != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// isVisibleString checks if a string contains only VisibleString characters
+func isVisibleString(s string) bool {
+	for _, r := range s {
+		if r < 0x20 || r > 0x7E {
+			return false
+		}
+	}
+	return true
+}
+
+// isGraphicString checks if a string contains only graphic characters and space.
+// Control characters (C0, DEL and C1) are rejected; escape sequences are not supported.
+func isGraphicString(s string) bool {
+	for _, r := range s {
+		if r < 0x20 || (r >= 0x7F && r <= 0x9F) || r > 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// isLatin1String checks if every character of a string fits in a single octet.
+// TeletexString, VideotexString and GeneralString values are carried this way.
+func isLatin1String(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if r > 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// isBMPString checks if a string contains only Basic Multilingual Plane characters
+func isBMPString(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if r > 0xFFFF {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeLatin1 encodes a string using one octet per character
+func encodeLatin1(s string) []byte {
+	result := make([]byte, 0, len(s))
+	for _, r := range s {
+		result = append(result, byte(r))
+	}
+	return result
+}
+
+// decodeLatin1 decodes octets into a string, mapping each octet to one character
+func decodeLatin1(data []byte) string {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// encodeBMPString encodes a string as big-endian UCS-2
+func encodeBMPString(s string) []byte {
+	result := make([]byte, 0, 2*len(s))
+	for _, r := range s {
+		result = append(result, byte(r>>8), byte(r))
+	}
+	return result
+}
+
+// decodeBMPString decodes big-endian UCS-2 octets into a string
+func decodeBMPString(data []byte) (string, error) {
+	if len(data)%2 != 0 {
+		return "", fmt.Errorf("BMPString length must be a multiple of 2, got %d", len(data))
+	}
+	runes := make([]rune, 0, len(data)/2)
+	for i := 0; i < len(data); i += 2 {
+		r := rune(data[i])<<8 | rune(data[i+1])
+		if utf16.IsSurrogate(r) {
+			return "", fmt.Errorf("BMPString contains surrogate code point U+%04X", r)
+		}
+		runes = append(runes, r)
+	}
+	return string(runes), nil
+}
+
+// encodeUniversalString encodes a string as big-endian UCS-4
+func encodeUniversalString(s string) []byte {
+	result := make([]byte, 0, 4*len(s))
+	for _, r := range s {
+		result = append(result, byte(r>>24), byte(r>>16), byte(r>>8), byte(r))
+	}
+	return result
+}
+
+// decodeUniversalString decodes big-endian UCS-4 octets into a string
+func decodeUniversalString(data []byte) (string, error) {
+	if len(data)%4 != 0 {
+		return "", fmt.Errorf("UniversalString length must be a multiple of 4, got %d", len(data))
+	}
+	runes := make([]rune, 0, len(data)/4)
+	for i := 0; i < len(data); i += 4 {
+		r := rune(uint32(data[i])<<24 | uint32(data[i+1])<<16 | uint32(data[i+2])<<8 | uint32(data[i+3]))
+		if !utf8.ValidRune(r) {
+			return "", fmt.Errorf("UniversalString contains invalid code point 0x%08X", uint32(r))
+		}
+		runes = append(runes, r)
+	}
+	return string(runes), nil
+}
+
+// stringTypeTags maps struct tag type names to the universal tag number of the string type
+var stringTypeTags = map[string]int{
+	"utf8string":       TagUTF8String,
+	"numericstring":    TagNumericString,
+	"printablestring":  TagPrintableString,
+	"teletexstring":    TagTeletexString,
+	"t61string":        TagT61String,
+	"videotexstring":   TagVideotexString,
+	"ia5string":        TagIA5String,
+	"graphicstring":    TagGraphicString,
+	"visiblestring":    TagVisibleString,
+	"generalstring":    TagGeneralString,
+	"universalstring":  TagUniversalString,
+	"bmpstring":        TagBMPString,
+	"objectdescriptor": TagObjectDescriptor,
+}
+
+// decodeStringValue decodes and validates the content octets of a string type
+func decodeStringValue(tagNumber int, data []byte) (string, error) {
+	switch tagNumber {
+	case TagUTF8String:
+		if !utf8.Valid(data) {
+			return "", fmt.Errorf("invalid UTF-8 string")
+		}
+		return string(data), nil
+	case TagNumericString:
+		if !isNumericString(string(data)) {
+			return "", fmt.Errorf("string contains non-numeric characters")
+		}
+		return string(data), nil
+	case TagPrintableString:
+		if !isPrintableString(string(data)) {
+			return "", fmt.Errorf("string contains non-printable characters")
+		}
+		return string(data), nil
+	case TagIA5String:
+		if !isIA5String(string(data)) {
+			return "", fmt.Errorf("string contains non-IA5 characters")
+		}
+		return string(data), nil
+	case TagVisibleString:
+		if !isVisibleString(string(data)) {
+			return "", fmt.Errorf("string contains non-visible characters")
+		}
+		return string(data), nil
+	case TagGraphicString, TagObjectDescriptor:
+		value := decodeLatin1(data)
+		if !isGraphicString(value) {
+			return "", fmt.Errorf("string contains non-graphic characters")
+		}
+		return value, nil
+	case TagTeletexString, TagVideotexString, TagGeneralString:
+		return decodeLatin1(data), nil
+	case TagBMPString:
+		return decodeBMPString(data)
+	case TagUniversalString:
+		return decodeUniversalString(data)
+	default:
+		return "", fmt.Errorf("tag %d is not a string type", tagNumber)
+	}
+}
+
+// newStringObject creates the string object for the given universal tag number,
+// returning an error instead of panicking when the value is outside the type's alphabet
+func newStringObject(tagNumber int, value string) (ASN1Object, error) {
+	var valid bool
+	switch tagNumber {
+	case TagUTF8String, TagUniversalString:
+		valid = utf8.ValidString(value)
+	case TagNumericString:
+		valid = isNumericString(value)
+	case TagPrintableString:
+		valid = isPrintableString(value)
+	case TagIA5String:
+		valid = isIA5String(value)
+	case TagVisibleString:
+		valid = isVisibleString(value)
+	case TagGraphicString, TagObjectDescriptor:
+		valid = isGraphicString(value)
+	case TagTeletexString, TagVideotexString, TagGeneralString:
+		valid = isLatin1String(value)
+	case TagBMPString:
+		valid = isBMPString(value)
+	default:
+		return nil, fmt.Errorf("tag %d is not a string type", tagNumber)
+	}
+	if !valid {
+		return nil, fmt.Errorf("value %q is not valid for string tag %d", value, tagNumber)
+	}
+
+	switch tagNumber {
+	case TagUTF8String:
+		return &ASN1UTF8String{value: value}, nil
+	case TagNumericString:
+		return &ASN1NumericString{value: value}, nil
+	case TagPrintableString:
+		return &ASN1PrintableString{value: value}, nil
+	case TagTeletexString:
+		return &ASN1TeletexString{value: value}, nil
+	case TagVideotexString:
+		return &ASN1VideotexString{value: value}, nil
+	case TagIA5String:
+		return &ASN1IA5String{value: value}, nil
+	case TagGraphicString:
+		return &ASN1GraphicString{value: value}, nil
+	case TagVisibleString:
+		return &ASN1VisibleString{value: value}, nil
+	case TagGeneralString:
+		return &ASN1GeneralString{value: value}, nil
+	case TagUniversalString:
+		return &ASN1UniversalString{value: value}, nil
+	case TagObjectDescriptor:
+		return &ASN1ObjectDescriptor{value: value}, nil
+	default:
+		return &ASN1BMPString{value: value}, nil
+	}
+}
+
+// decodeStringObject decodes the content octets of a string type into its string object
+func decodeStringObject(tagNumber int, data []byte) (ASN1Object, error) {
+	value, err := decodeStringValue(tagNumber, data)
+	if err != nil {
+		return nil, err
+	}
+	return newStringObject(tagNumber, value)
+}
+
+// stringObjectValue returns the Go string held by any of the string types
+func stringObjectValue(obj ASN1Object) (string, bool) {
+	switch s := obj.(type) {
+	case *ASN1UTF8String:
+		return s.Value(), true
+	case *ASN1NumericString:
+		return s.Value(), true
+	case *ASN1PrintableString:
+		return s.Value(), true
+	case *ASN1TeletexString:
+		return s.Value(), true
+	case *ASN1VideotexString:
+		return s.Value(), true
+	case *ASN1IA5String:
+		return s.Value(), true
+	case *ASN1GraphicString:
+		return s.Value(), true
+	case *ASN1VisibleString:
+		return s.Value(), true
+	case *ASN1GeneralString:
+		return s.Value(), true
+	case *ASN1UniversalString:
+		return s.Value(), true
+	case *ASN1BMPString:
+		return s.Value(), true
+	case *ASN1ObjectDescriptor:
+		return s.Value(), true
+	default:
+		return "", false
+	}
+}
+
 // DecodeUTF8String decodes an ASN1UTF8String from BER-encoded data
 func DecodeUTF8String(data []byte) (*ASN1UTF8String, int, error) {
 	asn1Value, consumed, err := DecodeTLV(data)
@@ -166,11 +939,11 @@ func DecodeUTF8String(data []byte) (*ASN1UTF8String, int, error) {
 	}
 
 	value := string(asn1Value.value)
-	if !utf8.ValidString(value) {
-		return nil, 0, fmt.Errorf("invalid UTF-8 string")
+	s, err := NewUTF8StringChecked(value)
+	if err != nil {
+		return nil, 0, err
 	}
-
-	return NewUTF8String(value), consumed, nil
+	return s, consumed, nil
 }
 
 // DecodePrintableString decodes an ASN1PrintableString from BER-encoded data
@@ -185,11 +958,11 @@ func DecodePrintableString(data []byte) (*ASN1PrintableString, int, error) {
 	}
 
 	value := string(asn1Value.value)
-	if !isPrintableString(value) {
-		return nil, 0, fmt.Errorf("string contains non-printable characters")
+	s, err := NewPrintableStringChecked(value)
+	if err != nil {
+		return nil, 0, err
 	}
-
-	return NewPrintableString(value), consumed, nil
+	return s, consumed, nil
 }
 
 // DecodeIA5String decodes an ASN1IA5String from BER-encoded data
@@ -204,9 +977,216 @@ func DecodeIA5String(data []byte) (*ASN1IA5String, int, error) {
 	}
 
 	value := string(asn1Value.value)
-	if !isIA5String(value) {
-		return nil, 0, fmt.Errorf("string contains non-IA5 characters")
+	s, err := NewIA5StringChecked(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, consumed, nil
+}
+
+// DecodeNumericString decodes an ASN1NumericString from BER-encoded data
+func DecodeNumericString(data []byte) (*ASN1NumericString, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagNumericString {
+		return nil, 0, fmt.Errorf("expected NumericString tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	value, err := decodeStringValue(TagNumericString, asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := NewNumericStringChecked(value)
+	if err != nil {
+		return nil, 0, err
 	}
+	return s, consumed, nil
+}
 
-	return NewIA5String(value), consumed, nil
-}
\ No newline at end of file
+// DecodeVisibleString decodes an ASN1VisibleString from BER-encoded data
+func DecodeVisibleString(data []byte) (*ASN1VisibleString, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagVisibleString {
+		return nil, 0, fmt.Errorf("expected VisibleString tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	value, err := decodeStringValue(TagVisibleString, asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := NewVisibleStringChecked(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, consumed, nil
+}
+
+// DecodeTeletexString decodes an ASN1TeletexString from BER-encoded data
+func DecodeTeletexString(data []byte) (*ASN1TeletexString, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagTeletexString {
+		return nil, 0, fmt.Errorf("expected TeletexString tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	value, err := decodeStringValue(TagTeletexString, asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := NewTeletexStringChecked(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, consumed, nil
+}
+
+// DecodeVideotexString decodes an ASN1VideotexString from BER-encoded data
+func DecodeVideotexString(data []byte) (*ASN1VideotexString, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagVideotexString {
+		return nil, 0, fmt.Errorf("expected VideotexString tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	value, err := decodeStringValue(TagVideotexString, asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := NewVideotexStringChecked(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, consumed, nil
+}
+
+// DecodeGraphicString decodes an ASN1GraphicString from BER-encoded data
+func DecodeGraphicString(data []byte) (*ASN1GraphicString, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagGraphicString {
+		return nil, 0, fmt.Errorf("expected GraphicString tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	value, err := decodeStringValue(TagGraphicString, asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := NewGraphicStringChecked(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, consumed, nil
+}
+
+// DecodeGeneralString decodes an ASN1GeneralString from BER-encoded data
+func DecodeGeneralString(data []byte) (*ASN1GeneralString, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagGeneralString {
+		return nil, 0, fmt.Errorf("expected GeneralString tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	value, err := decodeStringValue(TagGeneralString, asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := NewGeneralStringChecked(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, consumed, nil
+}
+
+// DecodeBMPString decodes an ASN1BMPString from BER-encoded data
+func DecodeBMPString(data []byte) (*ASN1BMPString, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagBMPString {
+		return nil, 0, fmt.Errorf("expected BMPString tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	value, err := decodeStringValue(TagBMPString, asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := NewBMPStringChecked(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, consumed, nil
+}
+
+// DecodeUniversalString decodes an ASN1UniversalString from BER-encoded data
+func DecodeUniversalString(data []byte) (*ASN1UniversalString, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagUniversalString {
+		return nil, 0, fmt.Errorf("expected UniversalString tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	value, err := decodeStringValue(TagUniversalString, asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := NewUniversalStringChecked(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, consumed, nil
+}
+
+// DecodeObjectDescriptor decodes an ASN1ObjectDescriptor from BER-encoded data
+func DecodeObjectDescriptor(data []byte) (*ASN1ObjectDescriptor, int, error) {
+	asn1Value, consumed, err := DecodeTLV(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if asn1Value.tag.Class != 0 || asn1Value.tag.Number != TagObjectDescriptor {
+		return nil, 0, fmt.Errorf("expected ObjectDescriptor tag, got class=%d number=%d", asn1Value.tag.Class, asn1Value.tag.Number)
+	}
+
+	value, err := decodeStringValue(TagObjectDescriptor, asn1Value.value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := NewObjectDescriptorChecked(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, consumed, nil
+}
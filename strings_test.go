@@ -0,0 +1,207 @@
+package asn1
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRestrictedStringEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  ASN1Object
+		want []byte
+	}{
+		{"NumericString", NewNumericString("12 34"), []byte{0x12, 0x05, '1', '2', ' ', '3', '4'}},
+		{"VisibleString", NewVisibleString("Hi!"), []byte{0x1A, 0x03, 'H', 'i', '!'}},
+		{"TeletexString", NewTeletexString("café"), []byte{0x14, 0x04, 'c', 'a', 'f', 0xE9}},
+		{"VideotexString", NewVideotexString("ab"), []byte{0x15, 0x02, 'a', 'b'}},
+		{"GraphicString", NewGraphicString("a b"), []byte{0x19, 0x03, 'a', ' ', 'b'}},
+		{"GeneralString", NewGeneralString("EXAMPLE.COM"), append([]byte{0x1B, 0x0B}, "EXAMPLE.COM"...)},
+		{"BMPString", NewBMPString("Aé"), []byte{0x1E, 0x04, 0x00, 'A', 0x00, 0xE9}},
+		{"UniversalString", NewUniversalString("A😀"), []byte{0x1C, 0x08, 0x00, 0x00, 0x00, 'A', 0x00, 0x01, 0xF6, 0x00}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.obj.Encode()
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if !bytes.Equal(encoded, tt.want) {
+				t.Errorf("Encode() = %x, want %x", encoded, tt.want)
+			}
+
+			// The generic decoder should produce the same typed object
+			value, _, err := DecodeTLV(encoded)
+			if err != nil {
+				t.Fatalf("DecodeTLV() error = %v", err)
+			}
+			decoded := convertPrimitiveValue(value)
+			if decoded.String() != tt.obj.String() {
+				t.Errorf("decoded = %s, want %s", decoded.String(), tt.obj.String())
+			}
+		})
+	}
+}
+
+func TestRestrictedStringDecoders(t *testing.T) {
+	bmp, consumed, err := DecodeBMPString([]byte{0x1E, 0x04, 0x04, 0x1F, 0x04, 0x40})
+	if err != nil {
+		t.Fatalf("DecodeBMPString() error = %v", err)
+	}
+	if consumed != 6 || bmp.Value() != "Пр" {
+		t.Errorf("DecodeBMPString() = %q (%d bytes), want %q (6 bytes)", bmp.Value(), consumed, "Пр")
+	}
+
+	numeric, _, err := DecodeNumericString([]byte{0x12, 0x03, '0', '4', '2'})
+	if err != nil {
+		t.Fatalf("DecodeNumericString() error = %v", err)
+	}
+	if numeric.Value() != "042" {
+		t.Errorf("DecodeNumericString() = %q, want %q", numeric.Value(), "042")
+	}
+
+	invalid := []struct {
+		name   string
+		decode func() error
+	}{
+		{"numeric with letters", func() error { _, _, err := DecodeNumericString([]byte{0x12, 0x02, '1', 'a'}); return err }},
+		{"visible with control", func() error { _, _, err := DecodeVisibleString([]byte{0x1A, 0x01, 0x07}); return err }},
+		{"graphic with control", func() error { _, _, err := DecodeGraphicString([]byte{0x19, 0x01, 0x0A}); return err }},
+		{"bmp odd length", func() error { _, _, err := DecodeBMPString([]byte{0x1E, 0x03, 0x00, 0x41, 0x00}); return err }},
+		{"bmp surrogate", func() error { _, _, err := DecodeBMPString([]byte{0x1E, 0x02, 0xD8, 0x00}); return err }},
+		{"universal bad length", func() error { _, _, err := DecodeUniversalString([]byte{0x1C, 0x02, 0x00, 0x41}); return err }},
+		{"universal out of range", func() error {
+			_, _, err := DecodeUniversalString([]byte{0x1C, 0x04, 0x00, 0x11, 0x00, 0x00})
+			return err
+		}},
+		{"wrong tag", func() error { _, _, err := DecodeGeneralString([]byte{0x16, 0x01, 'a'}); return err }},
+	}
+	for _, tt := range invalid {
+		if err := tt.decode(); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+type DirectoryNames struct {
+	Numeric   string  `asn1:"numericstring"`
+	Visible   string  `asn1:"visiblestring"`
+	Teletex   string  `asn1:"t61string"`
+	Videotex  string  `asn1:"videotexstring"`
+	Graphic   string  `asn1:"graphicstring"`
+	Realm     string  `asn1:"generalstring"`
+	BMP       string  `asn1:"bmpstring"`
+	Universal string  `asn1:"universalstring"`
+	Tagged    *string `asn1:"bmpstring,optional,tag:0"`
+}
+
+func TestRestrictedStringMarshaling(t *testing.T) {
+	tagged := "Grüße"
+	original := &DirectoryNames{
+		Numeric:   "0123",
+		Visible:   "visible",
+		Teletex:   "Zürich",
+		Videotex:  "videotex",
+		Graphic:   "graphic",
+		Realm:     "EXAMPLE.COM",
+		BMP:       "日本",
+		Universal: "𝄞 clef",
+		Tagged:    &tagged,
+	}
+
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded DirectoryNames
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded.Numeric != original.Numeric || decoded.Visible != original.Visible ||
+		decoded.Teletex != original.Teletex || decoded.Videotex != original.Videotex ||
+		decoded.Graphic != original.Graphic || decoded.Realm != original.Realm ||
+		decoded.BMP != original.BMP || decoded.Universal != original.Universal {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", decoded, *original)
+	}
+	if decoded.Tagged == nil || *decoded.Tagged != tagged {
+		t.Errorf("Tagged mismatch: got %v, want %q", decoded.Tagged, tagged)
+	}
+
+	// Values outside the alphabet are rejected with an error rather than a panic
+	bad := *original
+	bad.Numeric = "12a"
+	if _, err := Marshal(&bad); err == nil {
+		t.Error("expected error for invalid NumericString")
+	}
+}
+
+func TestStringCheckedConstructors(t *testing.T) {
+	checked := map[string]func(string) error{
+		"UTF8String":       func(s string) error { _, err := NewUTF8StringChecked(s); return err },
+		"PrintableString":  func(s string) error { _, err := NewPrintableStringChecked(s); return err },
+		"IA5String":        func(s string) error { _, err := NewIA5StringChecked(s); return err },
+		"NumericString":    func(s string) error { _, err := NewNumericStringChecked(s); return err },
+		"VisibleString":    func(s string) error { _, err := NewVisibleStringChecked(s); return err },
+		"TeletexString":    func(s string) error { _, err := NewTeletexStringChecked(s); return err },
+		"VideotexString":   func(s string) error { _, err := NewVideotexStringChecked(s); return err },
+		"GraphicString":    func(s string) error { _, err := NewGraphicStringChecked(s); return err },
+		"GeneralString":    func(s string) error { _, err := NewGeneralStringChecked(s); return err },
+		"BMPString":        func(s string) error { _, err := NewBMPStringChecked(s); return err },
+		"UniversalString":  func(s string) error { _, err := NewUniversalStringChecked(s); return err },
+		"ObjectDescriptor": func(s string) error { _, err := NewObjectDescriptorChecked(s); return err },
+	}
+	invalid := map[string]string{
+		"UTF8String":       "\xff",
+		"PrintableString":  "a@b",
+		"IA5String":        "é",
+		"NumericString":    "12a",
+		"VisibleString":    "\n",
+		"TeletexString":    "€",
+		"VideotexString":   "€",
+		"GraphicString":    "\x01",
+		"GeneralString":    "€",
+		"BMPString":        "\U0001F600",
+		"UniversalString":  "\xff",
+		"ObjectDescriptor": "\x01",
+	}
+
+	for name, check := range checked {
+		if err := check("123"); err != nil {
+			t.Errorf("New%sChecked(%q) error = %v", name, "123", err)
+		}
+		if err := check(invalid[name]); err == nil {
+			t.Errorf("New%sChecked(%q) accepted an invalid value", name, invalid[name])
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewPrintableString() did not panic on an invalid value")
+		}
+	}()
+	NewPrintableString("a@b")
+}
+
+func TestBitStringCheckedConstructors(t *testing.T) {
+	if b, err := NewBitStringChecked([]byte{0x80}, 7); err != nil || b.BitLength() != 1 {
+		t.Errorf("NewBitStringChecked() = %v, %v", b, err)
+	}
+	for _, unusedBits := range []int{-1, 8} {
+		if _, err := NewBitStringChecked([]byte{0x80}, unusedBits); err == nil {
+			t.Errorf("NewBitStringChecked() accepted %d unused bits", unusedBits)
+		}
+	}
+	if _, err := NewBitStringChecked(nil, 3); err == nil {
+		t.Error("NewBitStringChecked() accepted unused bits without data")
+	}
+
+	if b, err := ParseBitString("101"); err != nil || b.ToBitString() != "101" {
+		t.Errorf("ParseBitString() = %v, %v", b, err)
+	}
+	if _, err := ParseBitString("10x"); err == nil {
+		t.Error("ParseBitString() accepted an invalid character")
+	}
+}
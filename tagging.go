@@ -0,0 +1,76 @@
+package asn1
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// isExplicitTag reports whether a field's context-specific tag is EXPLICIT.
+// The explicit and implicit options win over MarshalOptions.ExplicitTags.
+// A CHOICE is always tagged explicitly (X.680 31.2.7), so asking for
+// IMPLICIT tagging of one is an error.
+func isExplicitTag(t reflect.Type, info *fieldInfo, opts *MarshalOptions) (bool, error) {
+	if isChoiceType(t, info) {
+		if info.Implicit {
+			return false, fmt.Errorf("CHOICE type cannot be tagged IMPLICIT")
+		}
+		return true, nil
+	}
+	if info.Explicit {
+		return true, nil
+	}
+	if info.Implicit {
+		return false, nil
+	}
+	return opts.ExplicitTags, nil
+}
+
+// isChoiceType reports whether a field holds an untagged CHOICE
+func isChoiceType(t reflect.Type, info *fieldInfo) bool {
+	if info.Type == "choice" {
+		return true
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if info.Type != "auto" {
+		return false
+	}
+	return t == reflect.TypeOf(ASN1Choice{}) || lookupChoice(t) != nil
+}
+
+// applyContextTag gives an encoded field its context-specific tag
+func applyContextTag(obj ASN1Object, tagNum int, explicit bool) ASN1Object {
+	if !explicit {
+		// IMPLICIT tagging: replace the object's tag with context-specific tag
+		return replaceTag(obj, tagNum)
+	}
+
+	// EXPLICIT tagging: wrap the object with context-specific tag
+	// The wrapper is always constructed for EXPLICIT tagging
+	wrapped := NewStructured(NewContextSpecificTag(tagNum, true))
+	wrapped.Add(obj)
+	return wrapped
+}
+
+// removeContextTag undoes applyContextTag on a decoded element
+func removeContextTag(element ASN1Object, t reflect.Type, info *fieldInfo, explicit bool) ASN1Object {
+	if explicit {
+		// EXPLICIT tagging: unwrap to get the inner element
+		if wrapped, ok := element.(*ASN1Structured); ok {
+			if elements := wrapped.Elements(); len(elements) == 1 {
+				return elements[0]
+			}
+		}
+		return element
+	}
+
+	// IMPLICIT tagging: restore the original tag, from the Go type if the
+	// struct tag does not name one
+	if _, ok := universalTypeTag(info.Type); !ok && info.Type == "auto" {
+		if tag, ok := typeImplicitTag(t, nil); ok {
+			return restoreUniversalTag(element, tag)
+		}
+	}
+	return restoreTag(element, info.Type)
+}
@@ -0,0 +1,118 @@
+package asn1
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type taggedAlternatives struct {
+	Number *int64  `asn1:"integer,tag:0"`
+	Text   *string `asn1:"utf8string,tag:1,explicit"`
+}
+
+type taggedChoiceHolder struct {
+	Value taggedAlternatives `asn1:"choice"`
+}
+
+func TestChoiceAlternativeTagging(t *testing.T) {
+	number := int64(5)
+	text := "a"
+
+	tests := []struct {
+		name  string
+		value taggedChoiceHolder
+		want  []byte
+	}{
+		{"implicit", taggedChoiceHolder{Value: taggedAlternatives{Number: &number}}, []byte{0x30, 0x03, 0x80, 0x01, 0x05}},
+		{"explicit", taggedChoiceHolder{Value: taggedAlternatives{Text: &text}}, []byte{0x30, 0x05, 0xA1, 0x03, 0x0C, 0x01, 'a'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if !bytes.Equal(encoded, tt.want) {
+				t.Errorf("Marshal() = %x, want %x", encoded, tt.want)
+			}
+
+			var decoded taggedChoiceHolder
+			if err := Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if tt.value.Value.Number != nil && (decoded.Value.Number == nil || *decoded.Value.Number != number) {
+				t.Errorf("Unmarshal() = %+v", decoded.Value)
+			}
+			if tt.value.Value.Text != nil && (decoded.Value.Text == nil || *decoded.Value.Text != text) {
+				t.Errorf("Unmarshal() = %+v", decoded.Value)
+			}
+		})
+	}
+}
+
+func TestExplicitTagsOption(t *testing.T) {
+	type record struct {
+		A int64 `asn1:"integer,tag:0"`
+		B int64 `asn1:"integer,tag:1,implicit"`
+	}
+
+	opts := DefaultMarshalOptions()
+	opts.ExplicitTags = true
+	encoded, err := MarshalWithOptions(record{A: 1, B: 2}, opts)
+	if err != nil {
+		t.Fatalf("MarshalWithOptions() error = %v", err)
+	}
+	want := []byte{0x30, 0x08, 0xA0, 0x03, 0x02, 0x01, 0x01, 0x81, 0x01, 0x02}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("MarshalWithOptions() = %x, want %x", encoded, want)
+	}
+
+	var decoded record
+	if err := UnmarshalWithOptions(encoded, &decoded, opts); err != nil {
+		t.Fatalf("UnmarshalWithOptions() error = %v", err)
+	}
+	if decoded.A != 1 || decoded.B != 2 {
+		t.Errorf("UnmarshalWithOptions() = %+v", decoded)
+	}
+}
+
+func TestImplicitChoiceRejected(t *testing.T) {
+	type holder struct {
+		Value taggedAlternatives `asn1:"choice,tag:3,implicit"`
+	}
+
+	number := int64(5)
+	_, err := Marshal(holder{Value: taggedAlternatives{Number: &number}})
+	if err == nil || !strings.Contains(err.Error(), "IMPLICIT") {
+		t.Errorf("Marshal() error = %v, want IMPLICIT error", err)
+	}
+
+	if _, err := parseASN1Tag("integer,tag:0,explicit,implicit"); err == nil {
+		t.Error("parseASN1Tag() accepted explicit and implicit together")
+	}
+}
+
+func TestTaggedChoiceFieldIsExplicit(t *testing.T) {
+	type holder struct {
+		Value taggedAlternatives `asn1:"choice,tag:3"`
+	}
+
+	number := int64(5)
+	encoded, err := Marshal(holder{Value: taggedAlternatives{Number: &number}})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := []byte{0x30, 0x05, 0xA3, 0x03, 0x80, 0x01, 0x05}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("Marshal() = %x, want %x", encoded, want)
+	}
+
+	var decoded holder
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Value.Number == nil || *decoded.Value.Number != 5 {
+		t.Errorf("Unmarshal() = %+v", decoded.Value)
+	}
+}
@@ -2,14 +2,28 @@ package asn1
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 )
 
+// PrecisionAuto makes GeneralizedTime write as many fractional-second digits as
+// needed, without trailing zeros (the DER form)
+const PrecisionAuto = -1
+
+// TimeOptions controls how UTCTime and GeneralizedTime values are encoded
+type TimeOptions struct {
+	// Precision is the number of fractional-second digits written by GeneralizedTime (0-9),
+	// or PrecisionAuto. UTCTime cannot carry fractions and ignores it.
+	Precision int
+	// PreserveOffset writes the time's own zone offset (+hhmm/-hhmm) instead of
+	// converting to UTC and writing Z
+	PreserveOffset bool
+}
+
 // ASN1UTCTime represents an ASN.1 UTCTime value
 type ASN1UTCTime struct {
 	time time.Time
+	opts TimeOptions
 }
 
 // NewUTCTime creates a new UTCTime with the given time
@@ -19,6 +33,13 @@ func NewUTCTime(t time.Time) *ASN1UTCTime {
 	}
 }
 
+// NewUTCTimeWithOptions creates a new UTCTime with the given time and encoding options
+func NewUTCTimeWithOptions(t time.Time, opts TimeOptions) *ASN1UTCTime {
+	u := &ASN1UTCTime{opts: opts}
+	u.SetTime(t)
+	return u
+}
+
 // NewUTCTimeNow creates a new UTCTime with the current time
 func NewUTCTimeNow() *ASN1UTCTime {
 	return &ASN1UTCTime{
@@ -33,9 +54,18 @@ func (u *ASN1UTCTime) Time() time.Time {
 
 // SetTime sets the time value
 func (u *ASN1UTCTime) SetTime(t time.Time) {
+	if u.opts.PreserveOffset {
+		u.time = t
+		return
+	}
 	u.time = t.UTC()
 }
 
+// Options returns the encoding options
+func (u *ASN1UTCTime) Options() TimeOptions {
+	return u.opts
+}
+
 // Tag returns the ASN.1 tag for UTCTime
 func (u *ASN1UTCTime) Tag() Tag {
 	return NewUniversalTag(TagUTCTime, false)
@@ -44,8 +74,21 @@ func (u *ASN1UTCTime) Tag() Tag {
 // Encode returns the BER encoding of the UTCTime
 func (u *ASN1UTCTime) Encode() ([]byte, error) {
 	// UTCTime format: YYMMDDHHMMSSZ or YYMMDDHHMMSS+HHMM or YYMMDDHHMMSS-HHMM
-	// We'll use the Z (UTC) format for simplicity
-	timeStr := u.time.Format("060102150405Z")
+	t := u.time
+	if !u.opts.PreserveOffset {
+		t = t.UTC()
+	}
+
+	// Two-digit years only cover 1950-2049; anything else would silently wrap
+	if t.Year() < 1950 || t.Year() > 2049 {
+		return nil, fmt.Errorf("UTCTime cannot represent year %d (valid range 1950-2049)", t.Year())
+	}
+
+	zone, err := formatTimeZone(t)
+	if err != nil {
+		return nil, err
+	}
+	timeStr := t.Format("060102150405") + zone
 	return EncodeTLV(u.Tag(), []byte(timeStr))
 }
 
@@ -62,6 +105,7 @@ func (u *ASN1UTCTime) TaggedString() string {
 // ASN1GeneralizedTime represents an ASN.1 GeneralizedTime value
 type ASN1GeneralizedTime struct {
 	time time.Time
+	opts TimeOptions
 }
 
 // NewGeneralizedTime creates a new GeneralizedTime with the given time
@@ -71,6 +115,13 @@ func NewGeneralizedTime(t time.Time) *ASN1GeneralizedTime {
 	}
 }
 
+// NewGeneralizedTimeWithOptions creates a new GeneralizedTime with the given time and encoding options
+func NewGeneralizedTimeWithOptions(t time.Time, opts TimeOptions) *ASN1GeneralizedTime {
+	g := &ASN1GeneralizedTime{opts: opts}
+	g.SetTime(t)
+	return g
+}
+
 // NewGeneralizedTimeNow creates a new GeneralizedTime with the current time
 func NewGeneralizedTimeNow() *ASN1GeneralizedTime {
 	return &ASN1GeneralizedTime{
@@ -85,9 +136,18 @@ func (g *ASN1GeneralizedTime) Time() time.Time {
 
 // SetTime sets the time value
 func (g *ASN1GeneralizedTime) SetTime(t time.Time) {
+	if g.opts.PreserveOffset {
+		g.time = t
+		return
+	}
 	g.time = t.UTC()
 }
 
+// Options returns the encoding options
+func (g *ASN1GeneralizedTime) Options() TimeOptions {
+	return g.opts
+}
+
 // Tag returns the ASN.1 tag for GeneralizedTime
 func (g *ASN1GeneralizedTime) Tag() Tag {
 	return NewUniversalTag(TagGeneralizedTime, false)
@@ -95,9 +155,31 @@ func (g *ASN1GeneralizedTime) Tag() Tag {
 
 // Encode returns the BER encoding of the GeneralizedTime
 func (g *ASN1GeneralizedTime) Encode() ([]byte, error) {
-	// GeneralizedTime format: YYYYMMDDHHMMSSZ or YYYYMMDDHHMMSS+HHMM or YYYYMMDDHHMMSS-HHMM
-	// We'll use the Z (UTC) format for simplicity
-	timeStr := g.time.Format("20060102150405Z")
+	// GeneralizedTime format: YYYYMMDDHHMMSS[.fff]Z or YYYYMMDDHHMMSS[.fff]+HHMM or YYYYMMDDHHMMSS[.fff]-HHMM
+	t := g.time
+	if !g.opts.PreserveOffset {
+		t = t.UTC()
+	}
+
+	if t.Year() < 0 || t.Year() > 9999 {
+		return nil, fmt.Errorf("GeneralizedTime cannot represent year %d", t.Year())
+	}
+
+	layout := "20060102150405"
+	switch {
+	case g.opts.Precision == PrecisionAuto:
+		layout += ".999999999"
+	case g.opts.Precision < 0 || g.opts.Precision > 9:
+		return nil, fmt.Errorf("invalid GeneralizedTime precision: %d", g.opts.Precision)
+	case g.opts.Precision > 0:
+		layout += "." + strings.Repeat("0", g.opts.Precision)
+	}
+
+	zone, err := formatTimeZone(t)
+	if err != nil {
+		return nil, err
+	}
+	timeStr := t.Format(layout) + zone
 	return EncodeTLV(g.Tag(), []byte(timeStr))
 }
 
@@ -111,6 +193,50 @@ func (g *ASN1GeneralizedTime) TaggedString() string {
 	return fmt.Sprintf("%s GeneralizedTime: %s", g.Tag().TagString(), g.time.Format(time.RFC3339))
 }
 
+// NewRFC5280Time creates the time object RFC 5280 requires for certificate validity
+// and similar fields: UTCTime for years 1950 through 2049, GeneralizedTime otherwise.
+// Both are written in UTC with whole seconds.
+func NewRFC5280Time(t time.Time) ASN1Object {
+	return NewRFC5280TimeWithOptions(t, TimeOptions{})
+}
+
+// NewRFC5280TimeWithOptions chooses the type like NewRFC5280Time, by the year
+// that is written, and writes it with the given options instead of RFC 5280's
+// UTC and whole seconds
+func NewRFC5280TimeWithOptions(t time.Time, opts TimeOptions) ASN1Object {
+	if !opts.PreserveOffset {
+		t = t.UTC()
+	}
+	if year := t.Year(); year >= 1950 && year <= 2049 {
+		return NewUTCTimeWithOptions(t, opts)
+	}
+	return NewGeneralizedTimeWithOptions(t, opts)
+}
+
+// decodedTimeOptions are attached to decoded time values so that they re-encode
+// with the offset and fraction they were received with. Other details of the
+// input are not kept: re-encoding always writes seconds and a time zone, so a
+// UTCTime without seconds gains ":00", and a GeneralizedTime without a zone,
+// decoded as local time, gains the offset of time.Local.
+var decodedTimeOptions = TimeOptions{Precision: PrecisionAuto, PreserveOffset: true}
+
+// formatTimeZone returns Z for UTC or the +hhmm/-hhmm offset of the time's location
+func formatTimeZone(t time.Time) (string, error) {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return "Z", nil
+	}
+	if offset%60 != 0 {
+		return "", fmt.Errorf("time zone offset %ds is not a whole number of minutes", offset)
+	}
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, (offset%3600)/60), nil
+}
+
 // DecodeUTCTime decodes a UTCTime from BER data
 func DecodeUTCTime(data []byte) (*ASN1UTCTime, int, error) {
 	value, consumed, err := DecodeTLV(data)
@@ -128,7 +254,8 @@ func DecodeUTCTime(data []byte) (*ASN1UTCTime, int, error) {
 		return nil, 0, fmt.Errorf("failed to parse UTCTime: %w", err)
 	}
 
-	return NewUTCTime(timeValue), consumed, nil
+	// Keep the parsed location so that re-encoding keeps the offset
+	return &ASN1UTCTime{time: timeValue, opts: decodedTimeOptions}, consumed, nil
 }
 
 // DecodeGeneralizedTime decodes a GeneralizedTime from BER data
@@ -148,31 +275,18 @@ func DecodeGeneralizedTime(data []byte) (*ASN1GeneralizedTime, int, error) {
 		return nil, 0, fmt.Errorf("failed to parse GeneralizedTime: %w", err)
 	}
 
-	return NewGeneralizedTime(timeValue), consumed, nil
+	// Keep the parsed location and fraction so that re-encoding keeps them
+	return &ASN1GeneralizedTime{time: timeValue, opts: decodedTimeOptions}, consumed, nil
 }
 
-// parseUTCTime parses a UTCTime string
+// parseUTCTime parses a UTCTime string of the form YYMMDDhhmm[ss](Z|+hhmm|-hhmm).
+// The time zone offset is preserved in the location of the returned time.
 func parseUTCTime(timeStr string) (time.Time, error) {
-	// Remove any whitespace
-	timeStr = strings.TrimSpace(timeStr)
-	
-	if len(timeStr) < 11 {
-		return time.Time{}, fmt.Errorf("UTCTime string too short: %s", timeStr)
-	}
-
-	// Extract components
-	yearStr := timeStr[0:2]
-	monthStr := timeStr[2:4]
-	dayStr := timeStr[4:6]
-	hourStr := timeStr[6:8]
-	minStr := timeStr[8:10]
-	secStr := timeStr[10:12]
-
-	year, err := strconv.Atoi(yearStr)
+	year, rest, err := parseTimeDigits(timeStr, 2, "year")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid year: %s", yearStr)
+		return time.Time{}, err
 	}
-	
+
 	// UTCTime uses 2-digit years, with 50-99 meaning 1950-1999, and 00-49 meaning 2000-2049
 	if year >= 50 {
 		year += 1900
@@ -180,80 +294,176 @@ func parseUTCTime(timeStr string) (time.Time, error) {
 		year += 2000
 	}
 
-	month, err := strconv.Atoi(monthStr)
+	month, rest, err := parseTimeDigits(rest, 2, "month")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid month: %s", monthStr)
+		return time.Time{}, err
 	}
-
-	day, err := strconv.Atoi(dayStr)
+	day, rest, err := parseTimeDigits(rest, 2, "day")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid day: %s", dayStr)
+		return time.Time{}, err
 	}
-
-	hour, err := strconv.Atoi(hourStr)
+	hour, rest, err := parseTimeDigits(rest, 2, "hour")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid hour: %s", hourStr)
+		return time.Time{}, err
 	}
-
-	min, err := strconv.Atoi(minStr)
+	min, rest, err := parseTimeDigits(rest, 2, "minute")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid minute: %s", minStr)
+		return time.Time{}, err
 	}
 
-	sec, err := strconv.Atoi(secStr)
+	// Seconds are optional in BER
+	sec := 0
+	if len(rest) > 0 && isDigit(rest[0]) {
+		sec, rest, err = parseTimeDigits(rest, 2, "second")
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	// UTCTime always carries a time zone, and offsets always include minutes
+	if rest == "" {
+		return time.Time{}, fmt.Errorf("missing time zone in UTCTime %q", timeStr)
+	}
+	loc, err := parseTimeZone(rest, true)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid second: %s", secStr)
+		return time.Time{}, err
 	}
 
-	return time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC), nil
+	return makeTime(year, month, day, hour, min, sec, loc)
 }
 
-// parseGeneralizedTime parses a GeneralizedTime string
+// parseGeneralizedTime parses a GeneralizedTime string of the form
+// YYYYMMDDhh[mm[ss]][(.|,)fraction][Z|+hh[mm]|-hh[mm]].
+// The fraction applies to the last time component present. Without a time zone
+// the value is a local time; otherwise the offset is preserved in the location.
 func parseGeneralizedTime(timeStr string) (time.Time, error) {
-	// Remove any whitespace
-	timeStr = strings.TrimSpace(timeStr)
-	
-	if len(timeStr) < 15 {
-		return time.Time{}, fmt.Errorf("GeneralizedTime string too short: %s", timeStr)
-	}
-
-	// Extract components
-	yearStr := timeStr[0:4]
-	monthStr := timeStr[4:6]
-	dayStr := timeStr[6:8]
-	hourStr := timeStr[8:10]
-	minStr := timeStr[10:12]
-	secStr := timeStr[12:14]
-
-	year, err := strconv.Atoi(yearStr)
+	year, rest, err := parseTimeDigits(timeStr, 4, "year")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid year: %s", yearStr)
+		return time.Time{}, err
 	}
-
-	month, err := strconv.Atoi(monthStr)
+	month, rest, err := parseTimeDigits(rest, 2, "month")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid month: %s", monthStr)
+		return time.Time{}, err
 	}
-
-	day, err := strconv.Atoi(dayStr)
+	day, rest, err := parseTimeDigits(rest, 2, "day")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid day: %s", dayStr)
+		return time.Time{}, err
 	}
-
-	hour, err := strconv.Atoi(hourStr)
+	hour, rest, err := parseTimeDigits(rest, 2, "hour")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid hour: %s", hourStr)
+		return time.Time{}, err
 	}
 
-	min, err := strconv.Atoi(minStr)
+	// Minutes and seconds are optional; the fraction unit is the last one present
+	min, sec := 0, 0
+	unit := time.Hour
+	if len(rest) > 0 && isDigit(rest[0]) {
+		min, rest, err = parseTimeDigits(rest, 2, "minute")
+		if err != nil {
+			return time.Time{}, err
+		}
+		unit = time.Minute
+		if len(rest) > 0 && isDigit(rest[0]) {
+			sec, rest, err = parseTimeDigits(rest, 2, "second")
+			if err != nil {
+				return time.Time{}, err
+			}
+			unit = time.Second
+		}
+	}
+
+	var fraction time.Duration
+	if len(rest) > 0 && (rest[0] == '.' || rest[0] == ',') {
+		rest = rest[1:]
+		digits := 0
+		scale := unit
+		for digits < len(rest) && isDigit(rest[digits]) {
+			scale /= 10
+			fraction += time.Duration(rest[digits]-'0') * scale
+			digits++
+		}
+		if digits == 0 {
+			return time.Time{}, fmt.Errorf("missing fraction digits in GeneralizedTime %q", timeStr)
+		}
+		rest = rest[digits:]
+	}
+
+	loc := time.Local
+	if rest != "" {
+		loc, err = parseTimeZone(rest, false)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	t, err := makeTime(year, month, day, hour, min, sec, loc)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid minute: %s", minStr)
+		return time.Time{}, err
 	}
+	return t.Add(fraction), nil
+}
 
-	sec, err := strconv.Atoi(secStr)
+// parseTimeDigits parses exactly n decimal digits from the start of s and returns the rest
+func parseTimeDigits(s string, n int, field string) (int, string, error) {
+	if len(s) < n {
+		return 0, "", fmt.Errorf("missing %s", field)
+	}
+	value := 0
+	for i := 0; i < n; i++ {
+		if !isDigit(s[i]) {
+			return 0, "", fmt.Errorf("invalid %s: %q", field, s[:n])
+		}
+		value = value*10 + int(s[i]-'0')
+	}
+	return value, s[n:], nil
+}
+
+// parseTimeZone parses a Z designator or a +hh[mm]/-hh[mm] offset that must span all of s
+func parseTimeZone(s string, requireMinutes bool) (*time.Location, error) {
+	if s == "Z" {
+		return time.UTC, nil
+	}
+	if s == "" || (s[0] != '+' && s[0] != '-') {
+		return nil, fmt.Errorf("invalid time zone: %q", s)
+	}
+
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+	hours, rest, err := parseTimeDigits(s[1:], 2, "time zone hour")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid second: %s", secStr)
+		return nil, err
+	}
+	minutes := 0
+	if rest != "" || requireMinutes {
+		minutes, rest, err = parseTimeDigits(rest, 2, "time zone minute")
+		if err != nil {
+			return nil, err
+		}
 	}
+	if rest != "" {
+		return nil, fmt.Errorf("trailing data after time zone: %q", rest)
+	}
+	if hours > 23 || minutes > 59 {
+		return nil, fmt.Errorf("invalid time zone offset: %q", s)
+	}
+
+	return time.FixedZone("", sign*(hours*3600+minutes*60)), nil
+}
 
-	return time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC), nil
-}
\ No newline at end of file
+// makeTime builds a time and rejects components that are out of range
+// (time.Date would otherwise silently normalize them)
+func makeTime(year, month, day, hour, min, sec int, loc *time.Location) (time.Time, error) {
+	t := time.Date(year, time.Month(month), day, hour, min, sec, 0, loc)
+	if t.Year() != year || int(t.Month()) != month || t.Day() != day ||
+		t.Hour() != hour || t.Minute() != min || t.Second() != sec {
+		return time.Time{}, fmt.Errorf("invalid date/time: %04d-%02d-%02d %02d:%02d:%02d", year, month, day, hour, min, sec)
+	}
+	return t, nil
+}
+
+// isDigit reports whether b is an ASCII decimal digit
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
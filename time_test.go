@@ -0,0 +1,344 @@
+package asn1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseUTCTime(t *testing.T) {
+	plus2 := time.FixedZone("", 2*3600)
+	minus530 := time.FixedZone("", -(5*3600 + 30*60))
+
+	tests := []struct {
+		input      string
+		want       time.Time
+		wantOffset int
+	}{
+		{"231225103045Z", time.Date(2023, 12, 25, 10, 30, 45, 0, time.UTC), 0},
+		{"2312251030Z", time.Date(2023, 12, 25, 10, 30, 0, 0, time.UTC), 0},
+		{"500101000000Z", time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"491231235959Z", time.Date(2049, 12, 31, 23, 59, 59, 0, time.UTC), 0},
+		{"231225103045+0200", time.Date(2023, 12, 25, 10, 30, 45, 0, plus2), 7200},
+		{"2312251030-0530", time.Date(2023, 12, 25, 10, 30, 0, 0, minus530), -19800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseUTCTime(tt.input)
+			if err != nil {
+				t.Fatalf("parseUTCTime(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseUTCTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			if _, offset := got.Zone(); offset != tt.wantOffset {
+				t.Errorf("parseUTCTime(%q) offset = %d, want %d", tt.input, offset, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestParseUTCTimeInvalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"2312251030",      // no time zone
+		"23122510304",     // truncated seconds
+		"231225103045",    // no time zone
+		"231225103045Z0",  // trailing data
+		"231225103045+02", // offset without minutes
+		"231225103045+0200x",
+		"231325103045Z", // month 13
+		"230230103045Z", // February 30
+		"231225246045Z", // hour 24
+		"231225103060Z", // second 60
+		"2312251030.5Z", // fractions are not allowed in UTCTime
+		" 231225103045Z",
+		"23122510304aZ",
+	}
+
+	for _, input := range inputs {
+		if got, err := parseUTCTime(input); err == nil {
+			t.Errorf("parseUTCTime(%q) = %v, expected error", input, got)
+		}
+	}
+}
+
+func TestParseGeneralizedTime(t *testing.T) {
+	plus2 := time.FixedZone("", 2*3600)
+	minus3 := time.FixedZone("", -3*3600)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"20231225103045Z", time.Date(2023, 12, 25, 10, 30, 45, 0, time.UTC)},
+		{"20241231235959.123Z", time.Date(2024, 12, 31, 23, 59, 59, 123000000, time.UTC)},
+		{"20241231235959,5Z", time.Date(2024, 12, 31, 23, 59, 59, 500000000, time.UTC)},
+		{"20241231235959.123456789Z", time.Date(2024, 12, 31, 23, 59, 59, 123456789, time.UTC)},
+		{"202412312359Z", time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC)},
+		{"2024123123Z", time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC)},
+		{"2024123123.5Z", time.Date(2024, 12, 31, 23, 30, 0, 0, time.UTC)},
+		{"202412312359.25Z", time.Date(2024, 12, 31, 23, 59, 15, 0, time.UTC)},
+		{"20231225103045+0200", time.Date(2023, 12, 25, 10, 30, 45, 0, plus2)},
+		{"20231225103045.1-03", time.Date(2023, 12, 25, 10, 30, 45, 100000000, minus3)},
+		{"20231225103045", time.Date(2023, 12, 25, 10, 30, 45, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseGeneralizedTime(tt.input)
+			if err != nil {
+				t.Fatalf("parseGeneralizedTime(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseGeneralizedTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			_, gotOffset := got.Zone()
+			_, wantOffset := tt.want.Zone()
+			if gotOffset != wantOffset {
+				t.Errorf("parseGeneralizedTime(%q) offset = %d, want %d", tt.input, gotOffset, wantOffset)
+			}
+		})
+	}
+
+	local, err := parseGeneralizedTime("20231225103045")
+	if err != nil {
+		t.Fatalf("parseGeneralizedTime() error = %v", err)
+	}
+	if local.Location() != time.Local {
+		t.Errorf("time without zone should be local, got location %v", local.Location())
+	}
+}
+
+func TestParseGeneralizedTimeInvalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"202312",
+		"20231225103045.Z",     // empty fraction
+		"20231225103045ZZ",     // trailing data
+		"20231225103045+2",     // short offset
+		"20231225103045+02000", // long offset
+		"20231225103045+2400",  // offset hour out of range
+		"20231225103045 Z",
+		"20231232103045Z", // day 32
+		"20231225103Z",    // odd number of minute digits
+	}
+
+	for _, input := range inputs {
+		if got, err := parseGeneralizedTime(input); err == nil {
+			t.Errorf("parseGeneralizedTime(%q) = %v, expected error", input, got)
+		}
+	}
+}
+
+func TestDecodeTimePreservesOffset(t *testing.T) {
+	encoded := append([]byte{0x18, 0x13}, "20231225103045+0200"...)
+	decoded, _, err := DecodeGeneralizedTime(encoded)
+	if err != nil {
+		t.Fatalf("DecodeGeneralizedTime() error = %v", err)
+	}
+	if _, offset := decoded.Time().Zone(); offset != 7200 {
+		t.Errorf("offset = %d, want 7200", offset)
+	}
+	if decoded.Time().Hour() != 10 {
+		t.Errorf("hour = %d, want 10 (local to the offset)", decoded.Time().Hour())
+	}
+}
+
+func TestTimeEncodingOptions(t *testing.T) {
+	plus2 := time.FixedZone("CEST", 2*3600)
+	value := time.Date(2024, 6, 1, 12, 30, 45, 120000000, plus2)
+
+	tests := []struct {
+		name string
+		obj  ASN1Object
+		want string
+	}{
+		{"generalized default", NewGeneralizedTime(value), "20240601103045Z"},
+		{"generalized auto precision", NewGeneralizedTimeWithOptions(value, TimeOptions{Precision: PrecisionAuto}), "20240601103045.12Z"},
+		{"generalized fixed precision", NewGeneralizedTimeWithOptions(value, TimeOptions{Precision: 4}), "20240601103045.1200Z"},
+		{"generalized offset", NewGeneralizedTimeWithOptions(value, TimeOptions{PreserveOffset: true}), "20240601123045+0200"},
+		{"utc default", NewUTCTime(value), "240601103045Z"},
+		{"utc offset", NewUTCTimeWithOptions(value, TimeOptions{PreserveOffset: true}), "240601123045+0200"},
+		{"utc ignores precision", NewUTCTimeWithOptions(value, TimeOptions{Precision: 3}), "240601103045Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.obj.Encode()
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			decoded, _, err := DecodeTLV(encoded)
+			if err != nil {
+				t.Fatalf("DecodeTLV() error = %v", err)
+			}
+			if got := string(decoded.Value()); got != tt.want {
+				t.Errorf("Encode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewGeneralizedTimeWithOptions(value, TimeOptions{Precision: 10}).Encode(); err == nil {
+		t.Error("expected error for precision above 9")
+	}
+}
+
+func TestUTCTimeRange(t *testing.T) {
+	for _, year := range []int{1949, 2050, 2100} {
+		if _, err := NewUTCTime(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)).Encode(); err == nil {
+			t.Errorf("expected error encoding year %d as UTCTime", year)
+		}
+	}
+	for _, year := range []int{1950, 2049} {
+		if _, err := NewUTCTime(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)).Encode(); err != nil {
+			t.Errorf("unexpected error encoding year %d as UTCTime: %v", year, err)
+		}
+	}
+}
+
+func TestRFC5280Time(t *testing.T) {
+	if _, ok := NewRFC5280Time(time.Date(2049, 12, 31, 23, 59, 59, 0, time.UTC)).(*ASN1UTCTime); !ok {
+		t.Error("expected UTCTime for 2049")
+	}
+	if _, ok := NewRFC5280Time(time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC)).(*ASN1GeneralizedTime); !ok {
+		t.Error("expected GeneralizedTime for 2050")
+	}
+	if _, ok := NewRFC5280Time(time.Date(1949, 12, 31, 0, 0, 0, 0, time.UTC)).(*ASN1GeneralizedTime); !ok {
+		t.Error("expected GeneralizedTime for 1949")
+	}
+
+	type Validity struct {
+		NotBefore time.Time `asn1:"rfc5280time"`
+		NotAfter  time.Time `asn1:"rfc5280time"`
+	}
+	original := &Validity{
+		NotBefore: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2054, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	encoded, err := Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	// SEQUENCE { UTCTime, GeneralizedTime }
+	if encoded[2] != TagUTCTime || encoded[2+2+13] != TagGeneralizedTime {
+		t.Errorf("unexpected time types in %x", encoded)
+	}
+
+	var decoded Validity
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !decoded.NotBefore.Equal(original.NotBefore) || !decoded.NotAfter.Equal(original.NotAfter) {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", decoded, *original)
+	}
+}
+
+func TestRFC5280TimeBoundaryWithOffset(t *testing.T) {
+	east := time.FixedZone("", 2*60*60)
+	west := time.FixedZone("", -2*60*60)
+	opts := TimeOptions{PreserveOffset: true}
+	tests := []struct {
+		time time.Time
+		want string
+	}{
+		// The year written, not the UTC year, decides the type
+		{time.Date(2050, 1, 1, 1, 0, 0, 0, east), "\x18\x1320500101010000+0200"},
+		{time.Date(2049, 12, 31, 23, 0, 0, 0, west), "\x17\x11491231230000-0200"},
+		{time.Date(1950, 1, 1, 1, 0, 0, 0, east), "\x17\x11500101010000+0200"},
+		{time.Date(1949, 12, 31, 23, 0, 0, 0, west), "\x18\x1319491231230000-0200"},
+	}
+	for _, tt := range tests {
+		encoded, err := NewRFC5280TimeWithOptions(tt.time, opts).Encode()
+		if err != nil {
+			t.Errorf("Encode(%v) error = %v", tt.time, err)
+			continue
+		}
+		if string(encoded) != tt.want {
+			t.Errorf("Encode(%v) = %q, want %q", tt.time, encoded, tt.want)
+		}
+	}
+
+	// Converted to UTC, the UTC year decides
+	if _, ok := NewRFC5280TimeWithOptions(time.Date(2050, 1, 1, 1, 0, 0, 0, east), TimeOptions{}).(*ASN1UTCTime); !ok {
+		t.Error("expected UTCTime for 2049 in UTC")
+	}
+}
+
+func TestDecodedTimeReencodes(t *testing.T) {
+	inputs := [][]byte{
+		append([]byte{0x18, 0x13}, "20241231235959.123Z"...),
+		append([]byte{0x18, 0x13}, "20231225103045+0200"...),
+		append([]byte{0x17, 0x11}, "231225103045-0530"...),
+	}
+	for _, input := range inputs {
+		value, _, err := DecodeTLV(input)
+		if err != nil {
+			t.Fatalf("DecodeTLV(%x) error = %v", input, err)
+		}
+		reencoded, err := convertPrimitiveValue(value).Encode()
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+		if string(reencoded) != string(input) {
+			t.Errorf("re-encoded %q, want %q", reencoded[2:], input[2:])
+		}
+	}
+}
+
+func TestDecodedTimeReencodingNormalizes(t *testing.T) {
+	// Seconds omitted from a UTCTime are written as 00
+	utc, _, err := DecodeUTCTime(append([]byte{0x17, 0x0B}, "2312251030Z"...))
+	if err != nil {
+		t.Fatalf("DecodeUTCTime() error = %v", err)
+	}
+	encoded, err := utc.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if got := string(encoded[2:]); got != "231225103000Z" {
+		t.Errorf("re-encoded %q, want %q", got, "231225103000Z")
+	}
+
+	// A GeneralizedTime without a zone is local time and gains time.Local's offset
+	generalized, _, err := DecodeGeneralizedTime(append([]byte{0x18, 0x0E}, "20231225103045"...))
+	if err != nil {
+		t.Fatalf("DecodeGeneralizedTime() error = %v", err)
+	}
+	if generalized.Time().Location() != time.Local {
+		t.Errorf("location = %v, want Local", generalized.Time().Location())
+	}
+	encoded, err = generalized.Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	zone, err := formatTimeZone(time.Date(2023, 12, 25, 10, 30, 45, 0, time.Local))
+	if err != nil {
+		t.Skipf("time.Local cannot be encoded: %v", err)
+	}
+	if want := "20231225103045" + zone; string(encoded[2:]) != want {
+		t.Errorf("re-encoded %q, want %q", encoded[2:], want)
+	}
+}
+
+func TestMarshalTimeOptions(t *testing.T) {
+	type Event struct {
+		At time.Time `asn1:"generalizedtime"`
+	}
+	opts := DefaultMarshalOptions()
+	opts.TimeOptions = TimeOptions{Precision: PrecisionAuto}
+
+	original := &Event{At: time.Date(2024, 6, 1, 12, 0, 0, 500000000, time.UTC)}
+	encoded, err := MarshalWithOptions(original, opts)
+	if err != nil {
+		t.Fatalf("MarshalWithOptions failed: %v", err)
+	}
+
+	var decoded Event
+	if err := Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !decoded.At.Equal(original.At) {
+		t.Errorf("At = %v, want %v", decoded.At, original.At)
+	}
+}
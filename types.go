@@ -7,20 +7,41 @@ import (
 
 // ASN.1 Universal Class Tag Numbers
 const (
-	TagBoolean         = 1
-	TagInteger         = 2
-	TagBitString       = 3
-	TagOctetString     = 4
-	TagNull            = 5
-	TagOID             = 6
-	TagEnumerated      = 10
-	TagUTF8String      = 12
-	TagSequence        = 16
-	TagSet             = 17
-	TagPrintableString = 19
-	TagIA5String       = 22
-	TagUTCTime         = 23
-	TagGeneralizedTime = 24
+	TagBoolean          = 1
+	TagInteger          = 2
+	TagBitString        = 3
+	TagOctetString      = 4
+	TagNull             = 5
+	TagOID              = 6
+	TagObjectDescriptor = 7
+	TagExternal         = 8
+	TagEnumerated       = 10
+	TagEmbeddedPDV      = 11
+	TagUTF8String       = 12
+	TagRelativeOID      = 13
+	TagTime             = 14
+	TagSequence         = 16
+	TagSet              = 17
+	TagNumericString    = 18
+	TagPrintableString  = 19
+	TagTeletexString    = 20
+	TagT61String        = TagTeletexString
+	TagVideotexString   = 21
+	TagIA5String        = 22
+	TagUTCTime          = 23
+	TagGeneralizedTime  = 24
+	TagGraphicString    = 25
+	TagVisibleString    = 26
+	TagGeneralString    = 27
+	TagUniversalString  = 28
+	TagCharacterString  = 29
+	TagBMPString        = 30
+	TagDate             = 31
+	TagTimeOfDay        = 32
+	TagDateTime         = 33
+	TagDuration         = 34
+	TagOIDIRI           = 35
+	TagRelativeOIDIRI   = 36
 )
 
 // Tag represents an ASN.1 tag
@@ -108,6 +129,7 @@ type ASN1Object interface {
 type ASN1Value struct {
 	tag   Tag
 	value []byte
+	raw   []byte // verbatim encoding, set when decoded
 }
 
 // NewASN1Value creates a new ASN1Value
@@ -151,6 +173,7 @@ func (v *ASN1Value) TaggedString() string {
 type ASN1Structured struct {
 	tag      Tag
 	elements []ASN1Object
+	raw      []byte // verbatim encoding, set when decoded and cleared by Add
 }
 
 // NewSequence creates a new SEQUENCE
@@ -180,6 +203,7 @@ func NewStructured(tag Tag) *ASN1Structured {
 // Add adds an element to the structured object
 func (s *ASN1Structured) Add(element ASN1Object) {
 	s.elements = append(s.elements, element)
+	s.raw = nil
 }
 
 // Elements returns the elements of the structured object
@@ -212,7 +212,10 @@ func TestObjectIdentifier(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Test creation from components
-			oid := NewObjectIdentifier(tt.components)
+			oid, err := NewObjectIdentifier(tt.components)
+			if err != nil {
+				t.Fatalf("NewObjectIdentifier() error = %v", err)
+			}
 			
 			// Test encoding
 			encoded, err := oid.Encode()